@@ -4,7 +4,7 @@
 //
 // see https://github.com/mantidtech/tplr for documentation
 //
-// Usage: tplr [-f] [-o <output file>] [-d <data file>] [-t <template file>] [inline template]
+// Usage: tplr [-f] [--seed <number>] [--now <time>] [--color <when>] [-o <output file>] [-d <data file>] [-t <template file>] [inline template]
 // Usage: tplr [-h|-v]
 //
 // Where:
@@ -17,6 +17,9 @@
 // Options:
 //
 //	-f If the destination file already exits, overwrite it.  (default is to do nothing)
+//	--seed <number> Seed the random functions so that output is reproducible
+//	--now <time> Use the given time (in RFC3339 format) as the current time, so that output is reproducible
+//	--color <when> Add colour to the output: auto (when writing to a terminal and NO_COLOR isn't set), always or never
 //
 // Information:
 //
@@ -30,8 +33,10 @@ import (
 	"io"
 	"os"
 	"path"
+	"time"
 
 	"github.com/mantidtech/tplr"
+	"github.com/mantidtech/tplr/functions/console"
 )
 
 const templateName = "tplr"
@@ -44,6 +49,9 @@ func main() {
 	dataFile := s.String("d", "-", "File to read data from")
 	outputFile := s.String("o", "-", "Write the processed template to the named file")
 	force := s.Bool("f", false, "Overwrite the destination file if it already exits (otherwise do nothing)")
+	seed := s.Int64("seed", 0, "Seed the random functions so that output is reproducible")
+	now := s.String("now", "", "Use the given time (in RFC3339 format) as the current time")
+	color := s.String("color", "auto", "When to add colour to the output: auto, always or never")
 	help := s.Bool("h", false, "Shows this help message")
 	showVersion := s.Bool("v", false, "Display version information")
 
@@ -69,7 +77,28 @@ func main() {
 	if err != nil {
 		errorAndExit("Failed to read template file: %v\n", err)
 	}
-	t := tplr.New(templateName)
+	var opts []tplr.Option
+	s.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			opts = append(opts, tplr.WithSeed(*seed))
+		}
+	})
+	if *now != "" {
+		ts, errNow := time.Parse(time.RFC3339, *now)
+		if errNow != nil {
+			errorAndExit("Invalid time given for --now: %v\n", errNow)
+		}
+		opts = append(opts, tplr.WithNow(ts))
+	}
+	colorMode, err := console.ParseColorMode(*color)
+	if err != nil {
+		errorAndExit("Invalid value given for --color: %v\n", err)
+	}
+	if colorMode == console.ColorAuto && *outputFile != "-" {
+		colorMode = console.ColorNever // only stdout can be a terminal
+	}
+	opts = append(opts, tplr.WithColor(colorMode))
+	t := tplr.New(templateName, opts...)
 
 	err = t.Load(tpl)
 	if err != nil {
@@ -96,7 +125,7 @@ func showHelp() {
 	_, app := path.Split(os.Args[0])
 	fmt.Printf("%s version %s\n\n", app, tplr.Version())
 	fmt.Printf("Usage:\n")
-	fmt.Printf("\t%s [-f] [-o <output file>] [-d <data file>] [-t <template file>] [inline template]\n", app)
+	fmt.Printf("\t%s [-f] [--seed <number>] [--now <time>] [--color <when>] [-o <output file>] [-d <data file>] [-t <template file>] [inline template]\n", app)
 	fmt.Printf("\t%s [-h|-v]\n", app)
 	fmt.Print("\n")
 	fmt.Printf("\tWhere:\n")
@@ -107,6 +136,9 @@ func showHelp() {
 	fmt.Print("\t\n")
 	fmt.Printf("\tOptions:\n")
 	fmt.Printf("\t\t-f If the destination file already exits, overwrite it.  (default is to do nothing)\n")
+	fmt.Printf("\t\t--seed <number> Seed the random functions so that output is reproducible\n")
+	fmt.Printf("\t\t--now <time> Use the given time (in RFC3339 format) as the current time, so that output is reproducible\n")
+	fmt.Printf("\t\t--color <when> Add colour to the output: auto (when writing to a terminal and NO_COLOR isn't set), always or never\n")
 	fmt.Print("\t\n")
 	fmt.Printf("\tInformation:\n")
 	fmt.Printf("\t\t-h Prints this message\n")
@@ -5,28 +5,44 @@ import (
 	"text/template"
 
 	"github.com/mantidtech/tplr/functions/console"
+	"github.com/mantidtech/tplr/functions/crypto"
 	"github.com/mantidtech/tplr/functions/datetime"
+	"github.com/mantidtech/tplr/functions/dict"
 	"github.com/mantidtech/tplr/functions/encoding"
+	"github.com/mantidtech/tplr/functions/expr"
+	"github.com/mantidtech/tplr/functions/format"
 	"github.com/mantidtech/tplr/functions/helper"
 	"github.com/mantidtech/tplr/functions/list"
 	"github.com/mantidtech/tplr/functions/logic"
 	"github.com/mantidtech/tplr/functions/math"
+	"github.com/mantidtech/tplr/functions/query"
+	"github.com/mantidtech/tplr/functions/random"
 	"github.com/mantidtech/tplr/functions/strings"
+	"github.com/mantidtech/tplr/functions/table"
 	"github.com/mantidtech/tplr/functions/templates"
 )
 
 // All returns all the templating functions
 func All(t *template.Template) template.FuncMap {
-	return CombineFunctionLists(
+	fns := CombineFunctionLists(
 		strings.Functions(),
 		list.Functions(),
+		dict.Functions(),
+		query.Functions(),
 		logic.Functions(),
 		math.Functions(),
+		format.Functions(),
+		random.Functions(),
 		datetime.Functions(),
 		encoding.Functions(),
+		crypto.Functions(),
+		table.Functions(),
 		console.Functions(),
 		templates.Functions(t),
 	)
+
+	// expressions can call any of the other functions
+	return CombineFunctionLists(fns, expr.Functions(fns))
 }
 
 // CombineFunctionLists together from zero more supplied lists
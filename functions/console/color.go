@@ -0,0 +1,152 @@
+package console
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ColorMode selects when the colour and style functions add ANSI escape codes to their output
+type ColorMode string
+
+// The modes for adding colour
+const (
+	ColorAuto   ColorMode = "auto"   // only when stdout is a terminal, and NO_COLOR isn't set
+	ColorAlways ColorMode = "always" // always add colour
+	ColorNever  ColorMode = "never"  // never add colour
+)
+
+// ColorOutput is when to add colour, defaulting to ColorAuto.
+// It is defined as a variable so that it can be overridden as required (e.g. unit testing)
+var ColorOutput = ColorAuto
+
+// ParseColorMode returns the mode with the given name (one of auto, always or never)
+func ParseColorMode(mode string) (ColorMode, error) {
+	switch m := ColorMode(strings.ToLower(mode)); m {
+	case ColorAuto, ColorAlways, ColorNever:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown colour mode %q, expected one of auto, always or never", mode)
+}
+
+// colorEnabled returns true if the colour functions should add escape codes
+func colorEnabled() bool {
+	switch ColorOutput {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return IsTerminal()
+}
+
+// basicColors are the numbers of the named (16 colour) foreground colours.
+// Background colours are 10 more, and the bright variants 60 more
+var basicColors = map[string]int{
+	"black":   30,
+	"red":     31,
+	"green":   32,
+	"yellow":  33,
+	"blue":    34,
+	"magenta": 35,
+	"cyan":    36,
+	"white":   37,
+	"default": 39,
+}
+
+// colorCode returns the SGR parameters for the given colour, where base is 30 for foreground, or 40 for background
+func colorCode(base int, name any) (string, error) {
+	s := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(fmt.Sprint(name)))
+
+	if s == "gray" || s == "grey" {
+		s = "brightblack"
+	}
+	if n, ok := basicColors[strings.TrimPrefix(s, "bright")]; ok {
+		n += base - 30
+		if strings.HasPrefix(s, "bright") && s != "brightdefault" {
+			n += 60
+		}
+		return strconv.Itoa(n), nil
+	}
+
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 || n > 255 {
+			return "", fmt.Errorf("colour number %d is out of range (0 to 255)", n)
+		}
+		return fmt.Sprintf("%d;5;%d", base+8, n), nil
+	}
+
+	if h := strings.TrimPrefix(s, "#"); h != s {
+		if len(h) == 3 {
+			h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+		}
+		if rgb, err := strconv.ParseUint(h, 16, 32); err == nil && len(h) == 6 {
+			return fmt.Sprintf("%d;2;%d;%d;%d", base+8, rgb>>16, rgb>>8&0xff, rgb&0xff), nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown colour %q, expected a name (eg red or brightRed), a number from 0 to 255, or #rrggbb", name)
+}
+
+// sgr wraps the text with the escape codes to set, then reset the display attribute, when colour is enabled
+func sgr(set, reset string, text any) string {
+	s := fmt.Sprint(text)
+	if !colorEnabled() {
+		return s
+	}
+	return "\x1b[" + set + "m" + s + "\x1b[" + reset + "m"
+}
+
+// Color displays the text in the given colour, which is a name (eg red or brightRed), a number from 0 to 255,
+// or a hex (#rrggbb) truecolour
+func Color(color any, text any) (string, error) {
+	code, err := colorCode(30, color)
+	if err != nil {
+		return "", err
+	}
+	return sgr(code, "39", text), nil
+}
+
+// BgColor displays the text on a background of the given colour, which is a name (eg red or brightRed),
+// a number from 0 to 255, or a hex (#rrggbb) truecolour
+func BgColor(color any, text any) (string, error) {
+	code, err := colorCode(40, color)
+	if err != nil {
+		return "", err
+	}
+	return sgr(code, "49", text), nil
+}
+
+// Bold displays the text in bold
+func Bold(text any) string {
+	return sgr("1", "22", text)
+}
+
+// Dim displays the text faintly
+func Dim(text any) string {
+	return sgr("2", "22", text)
+}
+
+// Italic displays the text in italics
+func Italic(text any) string {
+	return sgr("3", "23", text)
+}
+
+// Underline displays the text underlined
+func Underline(text any) string {
+	return sgr("4", "24", text)
+}
+
+// Inverse displays the text with the foreground and background colours swapped
+func Inverse(text any) string {
+	return sgr("7", "27", text)
+}
+
+// Strikethrough displays the text crossed out
+func Strikethrough(text any) string {
+	return sgr("9", "29", text)
+}
@@ -0,0 +1,186 @@
+package console
+
+import (
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	// tests aren't usually run in a terminal
+	ColorOutput = ColorAlways
+}
+
+// TestColor provides unit test coverage for Color() and BgColor()
+func TestColor(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "named",
+			Template: `{{ color "red" "text" }}`,
+			Want:     "\x1b[31mtext\x1b[39m",
+		},
+		{
+			Name:     "bright",
+			Template: `{{ color "brightGreen" "text" }}`,
+			Want:     "\x1b[92mtext\x1b[39m",
+		},
+		{
+			Name:     "bright with separator",
+			Template: `{{ color "bright-green" "text" }}`,
+			Want:     "\x1b[92mtext\x1b[39m",
+		},
+		{
+			Name:     "grey",
+			Template: `{{ color "grey" "text" }}`,
+			Want:     "\x1b[90mtext\x1b[39m",
+		},
+		{
+			Name:     "256 colours",
+			Template: `{{ color 208 "text" }}`,
+			Want:     "\x1b[38;5;208mtext\x1b[39m",
+		},
+		{
+			Name:     "truecolour",
+			Template: `{{ color "#ff8000" "text" }}`,
+			Want:     "\x1b[38;2;255;128;0mtext\x1b[39m",
+		},
+		{
+			Name:     "short truecolour",
+			Template: `{{ color "#F80" "text" }}`,
+			Want:     "\x1b[38;2;255;136;0mtext\x1b[39m",
+		},
+		{
+			Name:     "pipeline",
+			Template: `{{ 42 | color "blue" }}`,
+			Want:     "\x1b[34m42\x1b[39m",
+		},
+		{
+			Name:     "background",
+			Template: `{{ bgColor "yellow" "text" }}`,
+			Want:     "\x1b[43mtext\x1b[49m",
+		},
+		{
+			Name:     "bright background",
+			Template: `{{ bgColor "brightWhite" "text" }}`,
+			Want:     "\x1b[107mtext\x1b[49m",
+		},
+		{
+			Name:     "256 colour background",
+			Template: `{{ bgColor "17" "text" }}`,
+			Want:     "\x1b[48;5;17mtext\x1b[49m",
+		},
+		{
+			Name:     "nested",
+			Template: `{{ "text" | color "red" | bgColor "black" }}`,
+			Want:     "\x1b[40m\x1b[31mtext\x1b[39m\x1b[49m",
+		},
+		{
+			Name:     "unknown name",
+			Template: `{{ color "puce" "text" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "number out of range",
+			Template: `{{ color 256 "text" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad hex",
+			Template: `{{ bgColor "#12345g" "text" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestStyles provides unit test coverage for Bold(), Dim(), Italic(), Underline(), Inverse() and Strikethrough()
+func TestStyles(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "bold",
+			Template: `{{ bold "text" }}`,
+			Want:     "\x1b[1mtext\x1b[22m",
+		},
+		{
+			Name:     "dim",
+			Template: `{{ dim "text" }}`,
+			Want:     "\x1b[2mtext\x1b[22m",
+		},
+		{
+			Name:     "italic",
+			Template: `{{ italic "text" }}`,
+			Want:     "\x1b[3mtext\x1b[23m",
+		},
+		{
+			Name:     "underline",
+			Template: `{{ underline "text" }}`,
+			Want:     "\x1b[4mtext\x1b[24m",
+		},
+		{
+			Name:     "inverse",
+			Template: `{{ inverse "text" }}`,
+			Want:     "\x1b[7mtext\x1b[27m",
+		},
+		{
+			Name:     "strikethrough",
+			Template: `{{ strikethrough "text" }}`,
+			Want:     "\x1b[9mtext\x1b[29m",
+		},
+		{
+			Name:     "combined",
+			Template: `{{ "text" | bold | underline }}`,
+			Want:     "\x1b[4m\x1b[1mtext\x1b[22m\x1b[24m",
+		},
+		{
+			Name:     "stripped",
+			Template: `{{ "text" | bold | color "red" | stripAnsi }}`,
+			Want:     "text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestColorOutput ensures that colour is only added when it's enabled
+func TestColorOutput(t *testing.T) {
+	defer func(m ColorMode) { ColorOutput = m }(ColorOutput)
+
+	ColorOutput = ColorNever
+	got, err := Color("red", "text")
+	require.NoError(t, err)
+	assert.Equal(t, "text", got)
+	assert.Equal(t, "text", Bold("text"))
+
+	ColorOutput = ColorAuto
+	t.Setenv("NO_COLOR", "1")
+	assert.Equal(t, "text", Bold("text"), "NO_COLOR is set")
+	t.Setenv("NO_COLOR", "")
+	assert.Equal(t, colorEnabled(), Bold("text") != "text", "auto follows whether stdout is a terminal")
+
+	ColorOutput = ColorAlways
+	assert.Equal(t, "\x1b[1mtext\x1b[22m", Bold("text"), "NO_COLOR is overridden")
+}
+
+// TestParseColorMode provides unit test coverage for ParseColorMode()
+func TestParseColorMode(t *testing.T) {
+	for in, want := range map[string]ColorMode{
+		"auto":   ColorAuto,
+		"always": ColorAlways,
+		"never":  ColorNever,
+		"Always": ColorAlways,
+	} {
+		got, err := ParseColorMode(in)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseColorMode("sometimes")
+	assert.Error(t, err)
+}
@@ -3,24 +3,88 @@ package console
 
 import (
 	"os"
+	"strconv"
+	"strings"
 	"text/template"
 
+	"github.com/mantidtech/tplr/functions/helper"
 	"golang.org/x/sys/unix"
 )
 
 // Functions are functions that don't have a more specific home
 func Functions() template.FuncMap {
 	return template.FuncMap{
-		"terminalWidth": TerminalWidth,
+		"terminalWidth":  TerminalWidth,
+		"terminalHeight": TerminalHeight,
+		"isTerminal":     IsTerminal,
+		"colorSupport":   ColorSupport,
+		"hr":             HorizontalRule,
+		"box":            Box,
+		"columns":        Columns,
+		"color":          Color,
+		"bgColor":        BgColor,
+		"bold":           Bold,
+		"dim":            Dim,
+		"italic":         Italic,
+		"underline":      Underline,
+		"inverse":        Inverse,
+		"strikethrough":  Strikethrough,
+		"stripAnsi":      helper.StripANSI,
 	}
 }
 
+// GetWinsize returns the size of the terminal that stdout is written to, or an error if it isn't a terminal.
+// It is defined as a variable so that it can be overridden as required (e.g. unit testing)
+var GetWinsize = func() (*unix.Winsize, error) {
+	return unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+}
+
 // TerminalWidth returns the number of columns that the terminal currently has,
-// or 0 if the program isn't run in one, or it can't otherwise be determined
+// falling back to the COLUMNS environment variable if the program isn't run in one,
+// or 0 if it can't otherwise be determined
 func TerminalWidth() int {
-	ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
-	if err != nil {
+	if ws, err := GetWinsize(); err == nil && ws.Col > 0 {
+		return int(ws.Col)
+	}
+	return envSize("COLUMNS")
+}
+
+// TerminalHeight returns the number of lines that the terminal currently has,
+// falling back to the LINES environment variable if the program isn't run in one,
+// or 0 if it can't otherwise be determined
+func TerminalHeight() int {
+	if ws, err := GetWinsize(); err == nil && ws.Row > 0 {
+		return int(ws.Row)
+	}
+	return envSize("LINES")
+}
+
+// envSize returns the (positive) number in the named environment variable, or 0 if there isn't one
+func envSize(name string) int {
+	n, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || n < 0 {
 		return 0
 	}
-	return int(ws.Col)
+	return n
+}
+
+// IsTerminal returns true if the output is written to a terminal
+func IsTerminal() bool {
+	_, err := GetWinsize()
+	return err == nil
+}
+
+// ColorSupport returns the colours that the output can be displayed with: "none" if colour isn't enabled (see ColorOutput),
+// otherwise "truecolor", "256" or "16" depending on the COLORTERM and TERM environment variables
+func ColorSupport() string {
+	if !colorEnabled() {
+		return "none"
+	}
+	if ct := os.Getenv("COLORTERM"); ct == "truecolor" || ct == "24bit" {
+		return "truecolor"
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return "256"
+	}
+	return "16"
 }
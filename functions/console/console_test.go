@@ -1,25 +1,30 @@
 package console
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/mantidtech/tplr/functions/helper"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
 )
 
 // TestMiscellaneousFunctions provides unit test coverage for MiscellaneousFunctions
 func TestMiscellaneousFunctions(t *testing.T) {
 	fn := Functions()
-	assert.Len(t, fn, 1, "weakly ensuring functions haven't been added/removed without updating tests")
+	assert.Len(t, fn, 16, "weakly ensuring functions haven't been added/removed without updating tests")
 }
 
 // TestTerminalWidth provides unit test coverage for TerminalWidth()
 func TestTerminalWidth(t *testing.T) {
+	withTerminal(t, nil)
+	t.Setenv("COLUMNS", "")
+
 	tests := []helper.TestSet{
 		{
-			Name:     "basic",
+			Name:     "no terminal",
 			Template: "{{ terminalWidth }}",
-			Want:     "0", // probably - depends on how/where the test is run
+			Want:     "0",
 			WantErr:  false,
 		},
 	}
@@ -28,3 +33,75 @@ func TestTerminalWidth(t *testing.T) {
 		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
 	}
 }
+
+// withTerminal replaces the terminal size for the duration of a test, with no terminal if the size is nil
+func withTerminal(t *testing.T, ws *unix.Winsize) {
+	t.Helper()
+	orig := GetWinsize
+	t.Cleanup(func() { GetWinsize = orig })
+	GetWinsize = func() (*unix.Winsize, error) {
+		if ws == nil {
+			return nil, errors.New("not a terminal")
+		}
+		return ws, nil
+	}
+}
+
+// TestTerminalSize provides unit test coverage for TerminalWidth(), TerminalHeight() and IsTerminal()
+func TestTerminalSize(t *testing.T) {
+	t.Setenv("COLUMNS", "100")
+	t.Setenv("LINES", "40")
+
+	withTerminal(t, &unix.Winsize{Col: 132, Row: 50})
+	assert.Equal(t, 132, TerminalWidth())
+	assert.Equal(t, 50, TerminalHeight())
+	assert.True(t, IsTerminal())
+
+	withTerminal(t, nil)
+	assert.Equal(t, 100, TerminalWidth(), "falls back to COLUMNS")
+	assert.Equal(t, 40, TerminalHeight(), "falls back to LINES")
+	assert.False(t, IsTerminal())
+
+	t.Setenv("COLUMNS", "")
+	t.Setenv("LINES", "lots")
+	assert.Equal(t, 0, TerminalWidth())
+	assert.Equal(t, 0, TerminalHeight())
+}
+
+// TestColorSupport provides unit test coverage for ColorSupport()
+func TestColorSupport(t *testing.T) {
+	defer func(m ColorMode) { ColorOutput = m }(ColorOutput)
+	t.Setenv("NO_COLOR", "")
+
+	tests := []struct {
+		name      string
+		mode      ColorMode
+		terminal  bool
+		term      string
+		colorTerm string
+		want      string
+	}{
+		{name: "never", mode: ColorNever, terminal: true, term: "xterm-256color", want: "none"},
+		{name: "auto without a terminal", mode: ColorAuto, term: "xterm-256color", want: "none"},
+		{name: "auto in a dumb terminal", mode: ColorAuto, terminal: true, term: "dumb", want: "none"},
+		{name: "auto in a terminal", mode: ColorAuto, terminal: true, term: "xterm", want: "16"},
+		{name: "256 colours", mode: ColorAuto, terminal: true, term: "xterm-256color", want: "256"},
+		{name: "truecolour", mode: ColorAuto, terminal: true, term: "xterm-256color", colorTerm: "truecolor", want: "truecolor"},
+		{name: "24 bit", mode: ColorAlways, colorTerm: "24bit", want: "truecolor"},
+		{name: "always", mode: ColorAlways, want: "16"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ColorOutput = tt.mode
+			t.Setenv("TERM", tt.term)
+			t.Setenv("COLORTERM", tt.colorTerm)
+			if tt.terminal {
+				withTerminal(t, &unix.Winsize{Col: 80, Row: 24})
+			} else {
+				withTerminal(t, nil)
+			}
+			assert.Equal(t, tt.want, ColorSupport())
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// defaultWidth is used for the width of the output when it can't be determined from the terminal
+const defaultWidth = 80
+
+// HorizontalRule returns a line of the given width, or the width of the terminal if it's not given
+func HorizontalRule(width ...int) string {
+	w := TerminalWidth()
+	if len(width) > 0 {
+		w = width[0]
+	} else if w == 0 {
+		w = defaultWidth
+	}
+	if w < 0 {
+		return ""
+	}
+	return strings.Repeat("─", w)
+}
+
+// Box draws a border around the content, with the title (if any, and not nil) in the top border
+func Box(title any, content any) string {
+	t := ""
+	if title != nil {
+		t = fmt.Sprint(title)
+	}
+	lines := strings.Split(strings.TrimSuffix(fmt.Sprint(content), "\n"), "\n")
+
+	w := 0
+	if t != "" {
+		t = " " + t + " "
+		w = helper.DisplayWidth(t) + 2
+	}
+	for _, l := range lines {
+		w = max(w, helper.DisplayWidth(l)+2)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("┌")
+	if t != "" {
+		sb.WriteString("─" + t)
+	}
+	sb.WriteString(strings.Repeat("─", w-helper.DisplayWidth(t)-min(len(t), 1)) + "┐\n")
+	for _, l := range lines {
+		sb.WriteString("│ " + l + strings.Repeat(" ", w-helper.DisplayWidth(l)-2) + " │\n")
+	}
+	sb.WriteString("└" + strings.Repeat("─", w) + "┘\n")
+	return sb.String()
+}
+
+// Columns lays out the items of the list in the given number of columns, in order down each column in turn.
+// The items are spread as evenly as possible, with any extra going in the first columns,
+// so there are fewer columns only when there are fewer items
+func Columns(n int, list any) (string, error) {
+	if n < 1 {
+		return "", fmt.Errorf("the number of columns should be at least 1, got %d", n)
+	}
+	l, size, err := helper.ListInfo(list)
+	if err != nil {
+		return "", err
+	}
+	if size == 0 {
+		return "", nil
+	}
+
+	n = min(n, size)
+	rows := (size + n - 1) / n
+	starts := make([]int, n+1) // the index of the first item in each column, and the end of the last
+	for c := 0; c < n; c++ {
+		starts[c+1] = starts[c] + size/n
+		if c < size%n {
+			starts[c+1]++
+		}
+	}
+
+	items := make([]string, size)
+	widths := make([]int, n)
+	for c := 0; c < n; c++ {
+		for i := starts[c]; i < starts[c+1]; i++ {
+			items[i] = fmt.Sprint(l.Index(i).Interface())
+			widths[c] = max(widths[c], helper.DisplayWidth(items[i]))
+		}
+	}
+
+	var sb strings.Builder
+	for r := 0; r < rows; r++ {
+		var line strings.Builder
+		for c := 0; c < n && starts[c]+r < starts[c+1]; c++ {
+			if c > 0 {
+				line.WriteString("  ")
+			}
+			v := items[starts[c]+r]
+			line.WriteString(v + strings.Repeat(" ", widths[c]-helper.DisplayWidth(v)))
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+	return sb.String(), nil
+}
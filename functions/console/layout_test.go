@@ -0,0 +1,139 @@
+package console
+
+import (
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// TestHorizontalRule provides unit test coverage for HorizontalRule()
+func TestHorizontalRule(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "given width",
+			Template: `{{ hr 5 }}`,
+			Want:     "─────",
+		},
+		{
+			Name:     "zero width",
+			Template: `{{ hr 0 }}`,
+			Want:     "",
+		},
+		{
+			Name:     "negative width",
+			Template: `{{ hr -1 }}`,
+			Want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestBox provides unit test coverage for Box()
+func TestBox(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "with title",
+			Template: `{{ box "Title" "some content\nover two lines" }}`,
+			Want: "" +
+				"┌─ Title ────────┐\n" +
+				"│ some content   │\n" +
+				"│ over two lines │\n" +
+				"└────────────────┘\n",
+		},
+		{
+			Name:     "without title",
+			Template: `{{ box "" "content\n" }}`,
+			Want: "" +
+				"┌─────────┐\n" +
+				"│ content │\n" +
+				"└─────────┘\n",
+		},
+		{
+			Name:     "nil title",
+			Template: `{{ box nil "content" }}`,
+			Want: "" +
+				"┌─────────┐\n" +
+				"│ content │\n" +
+				"└─────────┘\n",
+		},
+		{
+			Name:     "long title",
+			Template: `{{ box "A longer title" "short" }}`,
+			Want: "" +
+				"┌─ A longer title ─┐\n" +
+				"│ short            │\n" +
+				"└──────────────────┘\n",
+		},
+		{
+			Name:     "wide characters",
+			Template: `{{ box "日本" "語" }}`,
+			Want: "" +
+				"┌─ 日本 ─┐\n" +
+				"│ 語     │\n" +
+				"└────────┘\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestColumns provides unit test coverage for Columns()
+func TestColumns(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "down each column",
+			Template: `{{ columns 3 .L }}`,
+			Args:     helper.TestArgs{"L": []any{"one", "two", "three", "four", "five", "six", "seven"}},
+			Want: "" +
+				"one    four  six\n" +
+				"two    five  seven\n" +
+				"three\n",
+		},
+		{
+			Name:     "exactly n columns",
+			Template: `{{ columns 4 .L }}`,
+			Args:     helper.TestArgs{"L": []int{1, 2, 3, 4, 5}},
+			Want: "" +
+				"1  3  4  5\n" +
+				"2\n",
+		},
+		{
+			Name:     "fewer items than columns",
+			Template: `{{ columns 4 .L }}`,
+			Args:     helper.TestArgs{"L": []int{1, 2}},
+			Want:     "1  2\n",
+		},
+		{
+			Name:     "one column",
+			Template: `{{ columns 1 .L }}`,
+			Args:     helper.TestArgs{"L": []string{"a", "b"}},
+			Want:     "a\nb\n",
+		},
+		{
+			Name:     "empty",
+			Template: `{{ columns 2 .L }}`,
+			Args:     helper.TestArgs{"L": []string{}},
+			Want:     "",
+		},
+		{
+			Name:     "no columns",
+			Template: `{{ columns 0 .L }}`,
+			Args:     helper.TestArgs{"L": []string{"a"}},
+			WantErr:  true,
+		},
+		{
+			Name:     "not a list",
+			Template: `{{ columns 2 "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
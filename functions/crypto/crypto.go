@@ -0,0 +1,20 @@
+// Package crypto provides template functions for hashes, checksums and UUIDs
+package crypto
+
+import (
+	"text/template"
+)
+
+// Functions for hashing and generating identifiers
+func Functions() template.FuncMap {
+	return template.FuncMap{
+		"md5":        MD5,
+		"sha1":       SHA1,
+		"sha256":     SHA256,
+		"sha512":     SHA512,
+		"crc32":      CRC32,
+		"hmacSHA256": HMACSHA256,
+		"uuidv4":     UUIDv4,
+		"uuidv5":     UUIDv5,
+	}
+}
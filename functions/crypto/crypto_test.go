@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFunctions provides unit test coverage for Functions
+func TestFunctions(t *testing.T) {
+	fn := Functions()
+	assert.Len(t, fn, 8, "weakly ensuring functions haven't been added/removed without updating tests")
+}
+
+// TestHashes provides unit test coverage for MD5(), SHA1(), SHA256(), SHA512() and CRC32()
+func TestHashes(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "md5",
+			Template: `{{ md5 "abc" }}`,
+			Want:     "900150983cd24fb0d6963f7d28e17f72",
+		},
+		{
+			Name:     "sha1",
+			Template: `{{ sha1 "abc" }}`,
+			Want:     "a9993e364706816aba3e25717850c26c9cd0d89d",
+		},
+		{
+			Name:     "sha256",
+			Template: `{{ sha256 "abc" }}`,
+			Want:     "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		{
+			Name:     "sha256 of empty string",
+			Template: `{{ sha256 "" }}`,
+			Want:     "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		{
+			Name:     "sha512",
+			Template: `{{ sha512 "abc" }}`,
+			Want:     "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f",
+		},
+		{
+			Name:     "crc32",
+			Template: `{{ crc32 "abc" }}`,
+			Want:     "352441c2",
+		},
+		{
+			Name:     "crc32 padded",
+			Template: `{{ crc32 "" }}`,
+			Want:     "00000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestHMACSHA256 provides unit test coverage for HMACSHA256()
+func TestHMACSHA256(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "message",
+			Template: `{{ hmacSHA256 "key" "The quick brown fox jumps over the lazy dog" }}`,
+			Want:     "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		},
+		{
+			Name:     "empty",
+			Template: `{{ hmacSHA256 "" "" }}`,
+			Want:     "b613679a0814d9ec772f95d778c35fc5ff1697c493715653c6c712144292c5ad",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -0,0 +1,48 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+)
+
+// MD5 returns the md5 hash of the string, in hex
+func MD5(s string) string {
+	h := md5.Sum([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+// SHA1 returns the sha1 hash of the string, in hex
+func SHA1(s string) string {
+	h := sha1.Sum([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+// SHA256 returns the sha256 hash of the string, in hex
+func SHA256(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+// SHA512 returns the sha512 hash of the string, in hex
+func SHA512(s string) string {
+	h := sha512.Sum512([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+// CRC32 returns the (IEEE) crc32 checksum of the string, as 8 hex digits
+func CRC32(s string) string {
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(s)))
+}
+
+// HMACSHA256 returns the sha256 hmac of the string with the given key, in hex
+func HMACSHA256(key string, s string) string {
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))
+}
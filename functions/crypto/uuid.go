@@ -0,0 +1,77 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// uuid is a 128 bit universally unique identifier (RFC 4122)
+type uuid [16]byte
+
+// uuidNamespaces are the predefined namespaces for name based uuids, which can be referred to by name
+var uuidNamespaces = map[string]string{
+	"dns":  "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	"url":  "6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+	"oid":  "6ba7b812-9dad-11d1-80b4-00c04fd430c8",
+	"x500": "6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+}
+
+// String formats the uuid in its canonical form, eg 6ba7b810-9dad-11d1-80b4-00c04fd430c8
+func (u uuid) String() string {
+	h := hex.EncodeToString(u[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// setVersion sets the version and the RFC 4122 variant bits
+func (u *uuid) setVersion(v byte) {
+	u[6] = (u[6] & 0x0f) | v<<4
+	u[8] = (u[8] & 0x3f) | 0x80
+}
+
+// parseUUID parses a uuid in its canonical form, with or without the hyphens, braces or a urn:uuid: prefix
+func parseUUID(s string) (uuid, error) {
+	var u uuid
+	h := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), "urn:uuid:")
+	h = strings.ReplaceAll(strings.Trim(h, "{}"), "-", "")
+	if len(h) != 32 {
+		return u, fmt.Errorf("invalid uuid %q", s)
+	}
+	if _, err := hex.Decode(u[:], []byte(h)); err != nil {
+		return u, fmt.Errorf("invalid uuid %q", s)
+	}
+	return u, nil
+}
+
+// UUIDv4 returns a new random uuid
+func UUIDv4() (string, error) {
+	var u uuid
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	u.setVersion(4)
+	return u.String(), nil
+}
+
+// UUIDv5 returns the name based uuid for the name within the namespace, which is the same each time.
+// The namespace is either a uuid or one of the predefined "dns", "url", "oid" or "x500"
+func UUIDv5(namespace string, name string) (string, error) {
+	if ns, ok := uuidNamespaces[strings.ToLower(namespace)]; ok {
+		namespace = ns
+	}
+	ns, err := parseUUID(namespace)
+	if err != nil {
+		return "", fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	h := sha1.New()
+	h.Write(ns[:])
+	h.Write([]byte(name))
+
+	var u uuid
+	copy(u[:], h.Sum(nil))
+	u.setVersion(5)
+	return u.String(), nil
+}
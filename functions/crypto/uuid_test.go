@@ -0,0 +1,68 @@
+package crypto
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUUIDv4 provides unit test coverage for UUIDv4()
+func TestUUIDv4(t *testing.T) {
+	v4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	a, err := UUIDv4()
+	require.NoError(t, err)
+	assert.Regexp(t, v4, a)
+
+	b, err := UUIDv4()
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+}
+
+// TestUUIDv5 provides unit test coverage for UUIDv5()
+func TestUUIDv5(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "dns namespace",
+			Template: `{{ uuidv5 "dns" "python.org" }}`,
+			Want:     "886313e1-3b8a-5372-9b90-0c9aee199e5d",
+		},
+		{
+			Name:     "url namespace",
+			Template: `{{ uuidv5 "URL" "http://python.org/" }}`,
+			Want:     "4c565f0d-3f5a-5890-b41b-20cf47701c5e",
+		},
+		{
+			Name:     "uuid namespace",
+			Template: `{{ uuidv5 "6ba7b810-9dad-11d1-80b4-00c04fd430c8" "python.org" }}`,
+			Want:     "886313e1-3b8a-5372-9b90-0c9aee199e5d",
+		},
+		{
+			Name:     "urn namespace",
+			Template: `{{ uuidv5 "urn:uuid:{6BA7B8109DAD11D180B400C04FD430C8}" "python.org" }}`,
+			Want:     "886313e1-3b8a-5372-9b90-0c9aee199e5d",
+		},
+		{
+			Name:     "chained",
+			Template: `{{ uuidv5 (uuidv5 "dns" "example.com") "service" }}`,
+			Want:     "db22fa37-0cad-541a-a2a4-fe3fe728e6e9",
+		},
+		{
+			Name:     "bad namespace",
+			Template: `{{ uuidv5 "example" "python.org" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad hex",
+			Template: `{{ uuidv5 "6ba7b810-9dad-11d1-80b4-00c04fd430cz" "python.org" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -0,0 +1,267 @@
+package datetime
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// Day is the length of a day, ignoring daylight savings transitions
+const Day = 24 * time.Hour
+
+// Week is the length of a week, ignoring daylight savings transitions
+const Week = 7 * Day
+
+// longUnits matches the day and week units that time.ParseDuration doesn't know about
+var longUnits = regexp.MustCompile(`([0-9]*\.?[0-9]+)([dw])`)
+
+// Duration converts a string such as "90m", "1h30m" or "2d" to a duration.
+// In addition to the units understood by time.ParseDuration, "d" (days) and "w" (weeks) can be used.
+// Numbers are taken to be a number of seconds
+func Duration(d any) (time.Duration, error) {
+	switch v := d.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		return parseDuration(v)
+	}
+
+	s, err := helper.ToFloat(d)
+	if err != nil {
+		return 0, fmt.Errorf("can't convert %T to a duration", d)
+	}
+	return time.Duration(s * float64(time.Second)), nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	var err error
+	h := longUnits.ReplaceAllStringFunc(s, func(m string) string {
+		p := longUnits.FindStringSubmatch(m)
+		n, errP := strconv.ParseFloat(p[1], 64)
+		if errP != nil {
+			err = errP
+			return m
+		}
+		if p[2] == "w" {
+			n *= 7
+		}
+		return strconv.FormatFloat(n*24, 'f', -1, 64) + "h"
+	})
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	return time.ParseDuration(strings.ReplaceAll(h, " ", ""))
+}
+
+// toTime converts a value to a timestamp. Strings are parsed in any of the commonly used formats,
+// and numbers are treated as seconds since the unix epoch
+func toTime(t any) (time.Time, error) {
+	switch v := t.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v != nil {
+			return *v, nil
+		}
+	case string:
+		return detectTime(v)
+	case nil:
+	default:
+		s, err := helper.ToInt(v)
+		if err == nil {
+			return time.Unix(s, 0), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("can't convert %T to a time", t)
+}
+
+// timeOrNow returns the first of the optional timestamps, or the current time if none was given
+func timeOrNow(ts []any) (time.Time, error) {
+	if len(ts) == 0 {
+		return helper.Now(), nil
+	}
+	return toTime(ts[0])
+}
+
+// TimeAdd adds the duration to the given timestamp, or to the current time if none is given
+func TimeAdd(d any, ts ...any) (time.Time, error) {
+	dur, err := Duration(d)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(dur), nil
+}
+
+// TimeSub subtracts the duration from the given timestamp, or from the current time if none is given
+func TimeSub(d any, ts ...any) (time.Time, error) {
+	dur, err := Duration(d)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(-dur), nil
+}
+
+// TimeDiff returns the duration from the first timestamp until the second, or until the current time if
+// the second isn't given
+func TimeDiff(from any, to ...any) (time.Duration, error) {
+	f, err := toTime(from)
+	if err != nil {
+		return 0, err
+	}
+	t, err := timeOrNow(to)
+	if err != nil {
+		return 0, err
+	}
+	return t.Sub(f), nil
+}
+
+// TruncateTime rounds the given timestamp (or the current time) down to a multiple of the duration since the zero time
+func TruncateTime(d any, ts ...any) (time.Time, error) {
+	dur, err := Duration(d)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Truncate(dur), nil
+}
+
+// StartOfDay returns midnight at the start of the day of the given timestamp (or the current time)
+func StartOfDay(ts ...any) (time.Time, error) {
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return startOfDay(t), nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// StartOfWeek returns midnight on the Monday starting the week of the given timestamp (or the current time)
+func StartOfWeek(ts ...any) (time.Time, error) {
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	offset := (int(t.Weekday()) + 6) % 7 // days since monday
+	return startOfDay(t).AddDate(0, 0, -offset), nil
+}
+
+// StartOfMonth returns midnight on the first day of the month of the given timestamp (or the current time)
+func StartOfMonth(ts ...any) (time.Time, error) {
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	y, m, _ := t.Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location()), nil
+}
+
+// HumanDuration describes a duration briefly, eg "2h 3m".
+// Given a timestamp, it describes how long ago (or in the future) that is from the current time, eg "3 days ago"
+func HumanDuration(d any) (string, error) {
+	switch v := d.(type) {
+	case time.Time, *time.Time:
+		return relativeTime(v)
+	case string:
+		if _, err := parseDuration(v); err != nil {
+			return relativeTime(v)
+		}
+	}
+
+	dur, err := Duration(d)
+	if err != nil {
+		return "", err
+	}
+	return humanDuration(dur), nil
+}
+
+// durationUnits are used (largest first) to describe durations
+var durationUnits = []struct {
+	size time.Duration
+	name string
+}{
+	{Day, "d"},
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+	{time.Second, "s"},
+}
+
+func humanDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	if d < time.Second {
+		return sign + d.String()
+	}
+
+	var parts []string
+	for _, u := range durationUnits {
+		if n := d / u.size; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, u.name))
+			d -= n * u.size
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// relativeUnits are used (largest first) to describe the distance between two times
+var relativeUnits = []struct {
+	size time.Duration
+	name string
+}{
+	{365 * Day, "year"},
+	{30 * Day, "month"},
+	{Week, "week"},
+	{Day, "day"},
+	{time.Hour, "hour"},
+	{time.Minute, "minute"},
+}
+
+func relativeTime(ts any) (string, error) {
+	t, err := toTime(ts)
+	if err != nil {
+		return "", err
+	}
+
+	d := t.Sub(helper.Now())
+	future := d > 0
+	if d < 0 {
+		d = -d
+	}
+
+	for _, u := range relativeUnits {
+		n := d / u.size
+		if n == 0 {
+			continue
+		}
+		desc := fmt.Sprintf("%d %s", n, u.name)
+		if n > 1 {
+			desc += "s"
+		}
+		if future {
+			return "in " + desc, nil
+		}
+		return desc + " ago", nil
+	}
+	return "just now", nil
+}
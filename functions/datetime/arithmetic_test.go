@@ -0,0 +1,301 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// TestDuration provides unit test coverage for Duration()
+func TestDuration(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "minutes",
+			Template: `{{ duration "90m" }}`,
+			Want:     "1h30m0s",
+		},
+		{
+			Name:     "days and weeks",
+			Template: `{{ duration "1w 1.5d 2h" }}`,
+			Want:     "206h0m0s",
+		},
+		{
+			Name:     "negative",
+			Template: `{{ duration "-2d" }}`,
+			Want:     "-48h0m0s",
+		},
+		{
+			Name:     "seconds",
+			Template: `{{ duration 90 }}`,
+			Want:     "1m30s",
+		},
+		{
+			Name:     "duration",
+			Template: `{{ duration .D }}`,
+			Args:     helper.TestArgs{"D": 3 * time.Second},
+			Want:     "3s",
+		},
+		{
+			Name:     "bad unit",
+			Template: `{{ duration "3y" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad type",
+			Template: `{{ duration .D }}`,
+			Args:     helper.TestArgs{"D": []int{}},
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestTimeAdd provides unit test coverage for TimeAdd() and TimeSub()
+func TestTimeAdd(t *testing.T) {
+	ts := time.Date(2023, 5, 18, 21, 0, 51, 0, time.UTC)
+
+	tests := []helper.TestSet{
+		{
+			Name:     "add to now",
+			Template: `{{ timeAdd "1h" }}`,
+			Want:     "2020-08-29 03:14:00.1337 +0000 UTC",
+		},
+		{
+			Name:     "add to time",
+			Template: `{{ timeAdd "30d" .T }}`,
+			Args:     helper.TestArgs{"T": ts},
+			Want:     "2023-06-17 21:00:51 +0000 UTC",
+		},
+		{
+			Name:     "add to string",
+			Template: `{{ timeAdd "1m" "2023-05-18T21:00:51Z" }}`,
+			Want:     "2023-05-18 21:01:51 +0000 UTC",
+		},
+		{
+			Name:     "add to unix time",
+			Template: `{{ timeAdd 60 0 | timeToUnix }}`,
+			Want:     "60",
+		},
+		{
+			Name:     "subtract from now",
+			Template: `{{ timeSub "2h14m" }}`,
+			Want:     "2020-08-29 00:00:00.1337 +0000 UTC",
+		},
+		{
+			Name:     "subtract from time",
+			Template: `{{ timeSub "1w" .T }}`,
+			Args:     helper.TestArgs{"T": &ts},
+			Want:     "2023-05-11 21:00:51 +0000 UTC",
+		},
+		{
+			Name:     "bad duration",
+			Template: `{{ timeAdd "soon" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad time",
+			Template: `{{ timeAdd "1h" "yesterday" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad subtraction duration",
+			Template: `{{ timeSub "soon" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad subtraction time",
+			Template: `{{ timeSub "1h" .T }}`,
+			Args:     helper.TestArgs{"T": nil},
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestTimeDiff provides unit test coverage for TimeDiff()
+func TestTimeDiff(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "until now",
+			Template: `{{ timeDiff "2020-08-28T00:00:00Z" }}`,
+			Want:     "26h14m0.1337s",
+		},
+		{
+			Name:     "between times",
+			Template: `{{ timeDiff "2023-05-18T21:00:51Z" "2023-05-18T20:00:00Z" }}`,
+			Want:     "-1h0m51s",
+		},
+		{
+			Name:     "bad from",
+			Template: `{{ timeDiff "then" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad to",
+			Template: `{{ timeDiff "2023-05-18T21:00:51Z" "then" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestTruncateTime provides unit test coverage for TruncateTime()
+func TestTruncateTime(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "now to the hour",
+			Template: `{{ truncateTime "1h" }}`,
+			Want:     "2020-08-29 02:00:00 +0000 UTC",
+		},
+		{
+			Name:     "time to 15 minutes",
+			Template: `{{ truncateTime "15m" "2023-05-18T21:29:51Z" }}`,
+			Want:     "2023-05-18 21:15:00 +0000 UTC",
+		},
+		{
+			Name:     "bad duration",
+			Template: `{{ truncateTime "hour" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad time",
+			Template: `{{ truncateTime "1h" "today" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestStartOf provides unit test coverage for StartOfDay(), StartOfWeek() and StartOfMonth()
+func TestStartOf(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "day now",
+			Template: `{{ startOfDay }}`,
+			Want:     "2020-08-29 00:00:00 +0000 UTC",
+		},
+		{
+			Name:     "day keeps zone",
+			Template: `{{ startOfDay "2023-05-18T21:29:51+10:00" | timeFormat "2006-01-02T15:04:05Z07:00" }}`,
+			Want:     "2023-05-18T00:00:00+10:00",
+		},
+		{
+			Name:     "week now",
+			Template: `{{ startOfWeek }}`,
+			Want:     "2020-08-24 00:00:00 +0000 UTC",
+		},
+		{
+			Name:     "week on a monday",
+			Template: `{{ startOfWeek "2023-05-15T21:29:51Z" }}`,
+			Want:     "2023-05-15 00:00:00 +0000 UTC",
+		},
+		{
+			Name:     "week on a sunday",
+			Template: `{{ startOfWeek "2023-05-21T21:29:51Z" }}`,
+			Want:     "2023-05-15 00:00:00 +0000 UTC",
+		},
+		{
+			Name:     "month now",
+			Template: `{{ startOfMonth }}`,
+			Want:     "2020-08-01 00:00:00 +0000 UTC",
+		},
+		{
+			Name:     "bad day",
+			Template: `{{ startOfDay "today" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad week",
+			Template: `{{ startOfWeek "today" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad month",
+			Template: `{{ startOfMonth "today" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestHumanDuration provides unit test coverage for HumanDuration()
+func TestHumanDuration(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "hours and minutes",
+			Template: `{{ humanDuration "123m" }}`,
+			Want:     "2h 3m",
+		},
+		{
+			Name:     "all units",
+			Template: `{{ duration "26h1m1s" | humanDuration }}`,
+			Want:     "1d 2h 1m 1s",
+		},
+		{
+			Name:     "negative",
+			Template: `{{ humanDuration "-90s" }}`,
+			Want:     "-1m 30s",
+		},
+		{
+			Name:     "sub-second",
+			Template: `{{ humanDuration "250ms" }}`,
+			Want:     "250ms",
+		},
+		{
+			Name:     "seconds",
+			Template: `{{ humanDuration 3600 }}`,
+			Want:     "1h",
+		},
+		{
+			Name:     "days ago",
+			Template: `{{ humanDuration "2020-08-26T00:00:00Z" }}`,
+			Want:     "3 days ago",
+		},
+		{
+			Name:     "in the future",
+			Template: `{{ timeAdd "1h" | humanDuration }}`,
+			Want:     "in 1 hour",
+		},
+		{
+			Name:     "years",
+			Template: `{{ timeSub "800d" | humanDuration }}`,
+			Want:     "2 years ago",
+		},
+		{
+			Name:     "just now",
+			Template: `{{ timeSub "10s" | humanDuration }}`,
+			Want:     "just now",
+		},
+		{
+			Name:     "bad string",
+			Template: `{{ humanDuration "a while" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad type",
+			Template: `{{ humanDuration .D }}`,
+			Args:     helper.TestArgs{"D": []int{}},
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -0,0 +1,74 @@
+package datetime
+
+import (
+	"time"
+)
+
+// IsoWeek returns the ISO 8601 week number of the given timestamp (or the current time)
+func IsoWeek(ts ...any) (int, error) {
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return 0, err
+	}
+	_, w := t.ISOWeek()
+	return w, nil
+}
+
+// Weekday returns the name of the day of the week of the given timestamp (or the current time)
+func Weekday(ts ...any) (string, error) {
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return "", err
+	}
+	return t.Weekday().String(), nil
+}
+
+// DaysInMonth returns the number of days in the month of the given timestamp (or the current time)
+func DaysInMonth(ts ...any) (int, error) {
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return 0, err
+	}
+	return daysInMonth(t), nil
+}
+
+func daysInMonth(t time.Time) int {
+	y, m, _ := t.Date()
+	return time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// IsWeekend returns true if the given timestamp (or the current time) is on a Saturday or Sunday
+func IsWeekend(ts ...any) (bool, error) {
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return false, err
+	}
+	d := t.Weekday()
+	return d == time.Saturday || d == time.Sunday, nil
+}
+
+// Calendar returns the weeks of the month of the given timestamp (or the current time), for rendering as a table.
+// Each week is a list of 7 days of the month, starting on Monday, with 0 for the days that fall outside the month
+func Calendar(ts ...any) ([][]int, error) {
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return nil, err
+	}
+
+	y, m, _ := t.Date()
+	first := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(first.Weekday()) + 6) % 7 // days since monday
+	days := daysInMonth(t)
+
+	var weeks [][]int
+	for d := 1 - offset; d <= days; d += 7 {
+		week := make([]int, 7)
+		for i := range week {
+			if day := d + i; day >= 1 && day <= days {
+				week[i] = day
+			}
+		}
+		weeks = append(weeks, week)
+	}
+	return weeks, nil
+}
@@ -0,0 +1,130 @@
+package datetime
+
+import (
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// TestIsoWeek provides unit test coverage for IsoWeek()
+func TestIsoWeek(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "now",
+			Template: `{{ isoWeek }}`,
+			Want:     "35",
+		},
+		{
+			Name:     "start of year in previous year's week",
+			Template: `{{ isoWeek "2021-01-03" }}`,
+			Want:     "53",
+		},
+		{
+			Name:     "bad time",
+			Template: `{{ isoWeek "soon" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestWeekday provides unit test coverage for Weekday() and IsWeekend()
+func TestWeekday(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "now",
+			Template: `{{ weekday }} {{ isWeekend }}`,
+			Want:     "Saturday true",
+		},
+		{
+			Name:     "sunday",
+			Template: `{{ weekday "2023-05-21" }} {{ isWeekend "2023-05-21" }}`,
+			Want:     "Sunday true",
+		},
+		{
+			Name:     "weekday",
+			Template: `{{ weekday "2023-05-18" }} {{ isWeekend "2023-05-18" }}`,
+			Want:     "Thursday false",
+		},
+		{
+			Name:     "bad weekday",
+			Template: `{{ weekday "soon" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad weekend",
+			Template: `{{ isWeekend "soon" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestDaysInMonth provides unit test coverage for DaysInMonth()
+func TestDaysInMonth(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "now",
+			Template: `{{ daysInMonth }}`,
+			Want:     "31",
+		},
+		{
+			Name:     "leap year",
+			Template: `{{ daysInMonth "2024-02-10" }}`,
+			Want:     "29",
+		},
+		{
+			Name:     "non-leap year",
+			Template: `{{ daysInMonth "2100-02-10" }}`,
+			Want:     "28",
+		},
+		{
+			Name:     "bad time",
+			Template: `{{ daysInMonth "soon" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestCalendar provides unit test coverage for Calendar()
+func TestCalendar(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "now",
+			Template: `{{ range calendar }}{{ . }}{{ "\n" }}{{ end }}`,
+			Want: "[0 0 0 0 0 1 2]\n" +
+				"[3 4 5 6 7 8 9]\n" +
+				"[10 11 12 13 14 15 16]\n" +
+				"[17 18 19 20 21 22 23]\n" +
+				"[24 25 26 27 28 29 30]\n" +
+				"[31 0 0 0 0 0 0]\n",
+		},
+		{
+			Name:     "four weeks",
+			Template: `{{ range calendar "2021-02-14" }}{{ . }}{{ "\n" }}{{ end }}`,
+			Want: "[1 2 3 4 5 6 7]\n" +
+				"[8 9 10 11 12 13 14]\n" +
+				"[15 16 17 18 19 20 21]\n" +
+				"[22 23 24 25 26 27 28]\n",
+		},
+		{
+			Name:     "bad time",
+			Template: `{{ calendar "soon" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -0,0 +1,223 @@
+package datetime
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// cronSearchYears limits how far ahead to look for a matching time, for schedules that can never fire (eg "0 0 30 2 *")
+const cronSearchYears = 5
+
+// cronMacros are the shorthand schedules, and their equivalents
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+
+var dayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// cronField describes the allowed values of one of the fields of a schedule
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // names for the values, starting from min
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNames},
+	{name: "day of week", min: 0, max: 7, names: dayNames}, // 7 is also sunday
+}
+
+// schedule is a parsed cron expression
+type schedule struct {
+	minute, hour, dom, month, dow []bool
+	domAny, dowAny                bool
+}
+
+// parseCron parses a standard 5 field cron expression (minute hour day-of-month month day-of-week),
+// or one of the @yearly, @monthly, @weekly, @daily, @midnight or @hourly macros
+func parseCron(spec string) (*schedule, error) {
+	s := strings.TrimSpace(spec)
+	if m, ok := cronMacros[strings.ToLower(s)]; ok {
+		s = m
+	}
+
+	f := strings.Fields(s)
+	if len(f) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression %q should have %d fields, found %d", spec, len(cronFields), len(f))
+	}
+
+	values := make([][]bool, len(f))
+	for i, field := range f {
+		var err error
+		values[i], err = cronFields[i].parse(field)
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", spec, err)
+		}
+	}
+
+	dow := values[4]
+	dow[0] = dow[0] || dow[7]
+
+	return &schedule{
+		minute: values[0],
+		hour:   values[1],
+		dom:    values[2],
+		month:  values[3],
+		dow:    dow[:7],
+		domAny: strings.HasPrefix(f[2], "*"),
+		dowAny: strings.HasPrefix(f[4], "*"),
+	}, nil
+}
+
+// parse a comma separated list of values, ranges and steps into the set of matching values
+func (c cronField) parse(field string) ([]bool, error) {
+	set := make([]bool, c.max+1)
+	for _, part := range strings.Split(field, ",") {
+		rng, step, hasStep := strings.Cut(part, "/")
+
+		var lo, hi int
+		var err error
+		switch {
+		case rng == "*":
+			lo, hi = c.min, c.max
+		case strings.Contains(rng, "-"):
+			from, to, _ := strings.Cut(rng, "-")
+			if lo, err = c.value(from); err != nil {
+				return nil, err
+			}
+			if hi, err = c.value(to); err != nil {
+				return nil, err
+			}
+			if hi < lo {
+				return nil, fmt.Errorf("invalid %s range %q", c.name, rng)
+			}
+		default:
+			if lo, err = c.value(rng); err != nil {
+				return nil, err
+			}
+			hi = lo
+			if hasStep {
+				hi = c.max
+			}
+		}
+
+		inc := 1
+		if hasStep {
+			inc, err = strconv.Atoi(step)
+			if err != nil || inc < 1 {
+				return nil, fmt.Errorf("invalid %s step %q", c.name, step)
+			}
+		}
+
+		for v := lo; v <= hi; v += inc {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// value converts a number or name to a value for the field
+func (c cronField) value(s string) (int, error) {
+	for i, n := range c.names {
+		if strings.EqualFold(s, n) {
+			return c.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < c.min || v > c.max {
+		return 0, fmt.Errorf("invalid %s %q", c.name, s)
+	}
+	return v, nil
+}
+
+// matchesDay uses the cron convention that if both day of month and day of week are restricted,
+// a day matching either of them is used
+func (s *schedule) matchesDay(t time.Time) bool {
+	dom := s.dom[t.Day()]
+	dow := s.dow[t.Weekday()]
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	}
+	return dom || dow
+}
+
+// next returns the first time after t that the schedule fires
+func (s *schedule) next(t time.Time) (time.Time, error) {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		y, m, d := t.Date()
+		switch {
+		case !s.month[m]:
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(t):
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		case !s.hour[t.Hour()]:
+			t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, loc)
+		case !s.minute[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("cron expression never fires")
+}
+
+// CronNext returns the next count times that the cron expression fires after the given timestamp, or the current time.
+// Times are calculated in the time zone of the timestamp, and those skipped by daylight savings transitions don't fire
+func CronNext(spec string, count any, from ...any) ([]time.Time, error) {
+	s, err := parseCron(spec)
+	if err != nil {
+		return nil, err
+	}
+	n, err := helper.ToInt(count)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("count must not be negative, got %d", n)
+	}
+	t, err := timeOrNow(from)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]time.Time, n)
+	for i := range res {
+		t, err = s.next(t)
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", spec, err)
+		}
+		res[i] = t
+	}
+	return res, nil
+}
+
+// CronValid returns true if the cron expression can be parsed
+func CronValid(spec string) bool {
+	_, err := parseCron(spec)
+	return err == nil
+}
@@ -0,0 +1,170 @@
+package datetime
+
+import (
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// cronTimes is a template to display the results of cronNext
+const cronTimes = `{{ range . }}{{ timeFormat "Mon 2006-01-02 15:04" . }};{{ end }}`
+
+// TestCronNext provides unit test coverage for CronNext()
+func TestCronNext(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "daily",
+			Template: `{{ with cronNext "0 3 * * *" 3 }}` + cronTimes + `{{ end }}`,
+			Want:     "Sat 2020-08-29 03:00;Sun 2020-08-30 03:00;Mon 2020-08-31 03:00;",
+		},
+		{
+			Name:     "every 15 minutes",
+			Template: `{{ with cronNext "*/15 * * * *" 3 }}` + cronTimes + `{{ end }}`,
+			Want:     "Sat 2020-08-29 02:15;Sat 2020-08-29 02:30;Sat 2020-08-29 02:45;",
+		},
+		{
+			Name:     "weekdays by name",
+			Template: `{{ with cronNext "30 9 * * mon-fri" 2 }}` + cronTimes + `{{ end }}`,
+			Want:     "Mon 2020-08-31 09:30;Tue 2020-09-01 09:30;",
+		},
+		{
+			Name:     "lists and ranges",
+			Template: `{{ with cronNext "0 8-9,17 * * *" 4 }}` + cronTimes + `{{ end }}`,
+			Want:     "Sat 2020-08-29 08:00;Sat 2020-08-29 09:00;Sat 2020-08-29 17:00;Sun 2020-08-30 08:00;",
+		},
+		{
+			Name:     "sunday as 7",
+			Template: `{{ with cronNext "0 0 * * 7" 1 }}` + cronTimes + `{{ end }}`,
+			Want:     "Sun 2020-08-30 00:00;",
+		},
+		{
+			Name:     "day of month or week",
+			Template: `{{ with cronNext "0 0 1 * fri" 3 }}` + cronTimes + `{{ end }}`,
+			Want:     "Tue 2020-09-01 00:00;Fri 2020-09-04 00:00;Fri 2020-09-11 00:00;",
+		},
+		{
+			Name:     "month names and steps",
+			Template: `{{ with cronNext "0 12 1 jan/3 *" 3 }}` + cronTimes + `{{ end }}`,
+			Want:     "Thu 2020-10-01 12:00;Fri 2021-01-01 12:00;Thu 2021-04-01 12:00;",
+		},
+		{
+			Name:     "leap day",
+			Template: `{{ with cronNext "0 0 29 2 *" 2 }}` + cronTimes + `{{ end }}`,
+			Want:     "Thu 2024-02-29 00:00;Tue 2028-02-29 00:00;",
+		},
+		{
+			Name:     "macro",
+			Template: `{{ with cronNext "@monthly" 1 }}` + cronTimes + `{{ end }}`,
+			Want:     "Tue 2020-09-01 00:00;",
+		},
+		{
+			Name:     "from time",
+			Template: `{{ with cronNext "@hourly" 2 "2023-05-18T21:00:51+10:00" }}` + cronTimes + `{{ end }}`,
+			Want:     "Thu 2023-05-18 22:00;Thu 2023-05-18 23:00;",
+		},
+		{
+			Name:     "skipped by daylight savings",
+			Template: `{{ with inZone "Australia/Sydney" "2023-09-30T15:00:00Z" | cronNext "30 2 * * *" 2 }}{{ range . }}{{ . }};{{ end }}{{ end }}`,
+			Want:     "2023-10-02 02:30:00 +1100 AEDT;2023-10-03 02:30:00 +1100 AEDT;",
+		},
+		{
+			Name:     "none",
+			Template: `{{ cronNext "@daily" 0 }}`,
+			Want:     "[]",
+		},
+		{
+			Name:     "never fires",
+			Template: `{{ cronNext "0 0 30 2 *" 1 }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad expression",
+			Template: `{{ cronNext "0 3 * *" 1 }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad count",
+			Template: `{{ cronNext "@daily" "a" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "negative count",
+			Template: `{{ cronNext "@daily" -1 }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad time",
+			Template: `{{ cronNext "@daily" 1 "soon" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestCronValid provides unit test coverage for CronValid()
+func TestCronValid(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "valid",
+			Template: `{{ cronValid "0 3 * * *" }}`,
+			Want:     "true",
+		},
+		{
+			Name:     "complex",
+			Template: `{{ cronValid "5,10-20/5 */2 1-7 JAN-jun Sun,TUE" }}`,
+			Want:     "true",
+		},
+		{
+			Name:     "macro",
+			Template: `{{ cronValid "@Weekly" }}`,
+			Want:     "true",
+		},
+		{
+			Name:     "too few fields",
+			Template: `{{ cronValid "0 3 * *" }}`,
+			Want:     "false",
+		},
+		{
+			Name:     "out of range",
+			Template: `{{ cronValid "60 * * * *" }}`,
+			Want:     "false",
+		},
+		{
+			Name:     "bad name",
+			Template: `{{ cronValid "0 0 * * someday" }}`,
+			Want:     "false",
+		},
+		{
+			Name:     "backwards range",
+			Template: `{{ cronValid "0 5-3 * * *" }}`,
+			Want:     "false",
+		},
+		{
+			Name:     "bad range start",
+			Template: `{{ cronValid "0 a-3 * * *" }}`,
+			Want:     "false",
+		},
+		{
+			Name:     "bad range end",
+			Template: `{{ cronValid "0 3-a * * *" }}`,
+			Want:     "false",
+		},
+		{
+			Name:     "bad step",
+			Template: `{{ cronValid "*/0 * * * *" }}`,
+			Want:     "false",
+		},
+		{
+			Name:     "zero day",
+			Template: `{{ cronValid "0 0 0 * *" }}`,
+			Want:     "false",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -2,6 +2,7 @@
 package datetime
 
 import (
+	"fmt"
 	"text/template"
 	"time"
 
@@ -14,31 +15,68 @@ const Format = time.RFC3339
 // Functions operate on time and dates
 func Functions() template.FuncMap {
 	return template.FuncMap{
-		"now":        Now,
-		"timeFormat": TimeFormat,
-		"timeParse":  TimeParse,
-		"timeToUnix": TimeToUnix,
-		"unixToTime": UnixToTime,
+		"now":           Now,
+		"timeFormat":    TimeFormat,
+		"timeParse":     TimeParse,
+		"timeToUnix":    TimeToUnix,
+		"unixToTime":    UnixToTime,
+		"timeToUnixMS":  TimeToUnixMS,
+		"unixToTimeMS":  UnixToTimeMS,
+		"timeToUnixNS":  TimeToUnixNS,
+		"unixToTimeNS":  UnixToTimeNS,
+		"duration":      Duration,
+		"timeAdd":       TimeAdd,
+		"timeSub":       TimeSub,
+		"timeDiff":      TimeDiff,
+		"truncateTime":  TruncateTime,
+		"startOfDay":    StartOfDay,
+		"startOfWeek":   StartOfWeek,
+		"startOfMonth":  StartOfMonth,
+		"humanDuration": HumanDuration,
+		"inZone":        InZone,
+		"utc":           UTC,
+		"localTime":     LocalTime,
+		"cronNext":      CronNext,
+		"cronValid":     CronValid,
+		"isoWeek":       IsoWeek,
+		"weekday":       Weekday,
+		"daysInMonth":   DaysInMonth,
+		"isWeekend":     IsWeekend,
+		"calendar":      Calendar,
 	}
 }
 
-// Now returns the current time in the format "2006-01-02T15:04:05Z07:00" (RFC3339)
-func Now(format ...string) string {
+// Now returns the current time in the given format, or "2006-01-02T15:04:05Z07:00" (RFC3339) if none is given.
+// The format can be a go layout, a strftime pattern (eg "%Y-%m-%d") or the name of a format (eg "rfc1123")
+func Now(format ...string) (string, error) {
 	f := Format
 	if len(format) > 0 {
 		f = format[0]
 	}
-	return helper.Now().Format(f)
+	return formatTime(f, helper.Now())
 }
 
-// TimeFormat formats the given timestamp with the given format
-func TimeFormat(format string, ts time.Time) string {
-	return ts.Format(format)
+// TimeFormat formats the given timestamp with the given format.
+// The format can be a go layout, a strftime pattern (eg "%Y-%m-%d") or the name of a format (eg "rfc1123")
+func TimeFormat(format string, ts any) (string, error) {
+	t, err := toTime(ts)
+	if err != nil {
+		return "", err
+	}
+	return formatTime(format, t)
 }
 
-// TimeParse parses the given string using the given format
-func TimeParse(format string, ts string) (time.Time, error) {
-	return time.Parse(format, ts)
+// TimeParse parses the given string using the given format.
+// The format can be a go layout, a strftime pattern (eg "%Y-%m-%d") or the name of a format (eg "rfc1123").
+// If only the string is given, its format is detected from those commonly used
+func TimeParse(format string, ts ...string) (time.Time, error) {
+	switch len(ts) {
+	case 0:
+		return detectTime(format)
+	case 1:
+		return parseTime(format, ts[0])
+	}
+	return time.Time{}, fmt.Errorf("expected a format and a time, got %d arguments", len(ts)+1)
 }
 
 // TimeToUnix converts the given timestamp to the number of seconds since the unix epoch
@@ -51,19 +89,22 @@ func UnixToTime(s int) time.Time {
 	return time.Unix(int64(s), 0)
 }
 
-//
-// func TimeToUnixMS(ts time.Time) int64 {
-// 	return ts.UnixNano() / 1_000_000
-// }
-//
-// func UnixToTimeMS(ms int) time.Time {
-// 	return time.Unix(0, int64(ms)*1_000_000)
-// }
-//
-// func TimeToUnixNS(ts time.Time) int64 {
-// 	return ts.UnixNano()
-// }
-//
-// func UnixToTimeNS(s int) time.Time {
-// 	return time.Unix(0, int64(s))
-// }
+// TimeToUnixMS converts the given timestamp to the number of milliseconds since the unix epoch
+func TimeToUnixMS(ts time.Time) int64 {
+	return ts.UnixMilli()
+}
+
+// UnixToTimeMS converts the given number of milliseconds, as a unix epoch, to a timestamp
+func UnixToTimeMS(ms int) time.Time {
+	return time.UnixMilli(int64(ms))
+}
+
+// TimeToUnixNS converts the given timestamp to the number of nanoseconds since the unix epoch
+func TimeToUnixNS(ts time.Time) int64 {
+	return ts.UnixNano()
+}
+
+// UnixToTimeNS converts the given number of nanoseconds, as a unix epoch, to a timestamp
+func UnixToTimeNS(ns int) time.Time {
+	return time.Unix(0, int64(ns))
+}
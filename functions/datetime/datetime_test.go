@@ -18,7 +18,7 @@ func init() {
 // TestListFunctions provides unit test coverage for ListFunctions
 func TestFunctions(t *testing.T) {
 	fn := Functions()
-	assert.Len(t, fn, 5, "weakly ensuring functions haven't been added/removed without updating tests")
+	assert.Len(t, fn, 28, "weakly ensuring functions haven't been added/removed without updating tests")
 }
 
 // TestNow provides unit test coverage for Now.
@@ -149,3 +149,53 @@ func TestUnixToTime(t *testing.T) {
 		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
 	}
 }
+
+// TestUnixMSNS provides unit test coverage for TimeToUnixMS(), UnixToTimeMS(), TimeToUnixNS() and UnixToTimeNS()
+func TestUnixMSNS(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "milliseconds",
+			Template: `{{ unixToTimeMS .I | timeToUnixMS }}`,
+			Args: helper.TestArgs{
+				"I": 1684407651123,
+			},
+			Want: "1684407651123",
+		},
+		{
+			Name:     "milliseconds to time",
+			Template: `{{ unixToTimeMS .I | utc }}`,
+			Args: helper.TestArgs{
+				"I": 1684407651123,
+			},
+			Want: "2023-05-18 11:00:51.123 +0000 UTC",
+		},
+		{
+			Name:     "nanoseconds",
+			Template: `{{ unixToTimeNS .I | timeToUnixNS }}`,
+			Args: helper.TestArgs{
+				"I": 1684407651123456789,
+			},
+			Want: "1684407651123456789",
+		},
+		{
+			Name:     "nanoseconds to seconds",
+			Template: `{{ unixToTimeNS .I | timeToUnix }}`,
+			Args: helper.TestArgs{
+				"I": 1684407651123456789,
+			},
+			Want: "1684407651",
+		},
+		{
+			Name:     "string",
+			Template: `{{ unixToTimeMS .S }}`,
+			Args: helper.TestArgs{
+				"S": "1684407651123",
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -0,0 +1,334 @@
+package datetime
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NamedFormats are the layouts that can be referred to by name (case-insensitively) instead of a go layout
+var NamedFormats = map[string]string{
+	"ansic":       time.ANSIC,
+	"unixdate":    time.UnixDate,
+	"rubydate":    time.RubyDate,
+	"rfc822":      time.RFC822,
+	"rfc822z":     time.RFC822Z,
+	"rfc850":      time.RFC850,
+	"rfc1123":     time.RFC1123,
+	"rfc1123z":    time.RFC1123Z,
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+	"iso8601":     "2006-01-02T15:04:05.000Z07:00",
+	"kitchen":     time.Kitchen,
+	"stamp":       time.Stamp,
+	"date":        time.DateOnly,
+	"time":        time.TimeOnly,
+	"datetime":    time.DateTime,
+}
+
+// unixFormats are the named formats for numeric timestamps, with the size of their units
+var unixFormats = map[string]time.Duration{
+	"unix":   time.Second,
+	"unixms": time.Millisecond,
+	"unixus": time.Microsecond,
+	"unixns": time.Nanosecond,
+}
+
+// strftimeDirectives maps strftime directives to their equivalent go layouts
+var strftimeDirectives = map[string]string{
+	"a":  "Mon",
+	"A":  "Monday",
+	"b":  "Jan",
+	"h":  "Jan",
+	"B":  "January",
+	"c":  "Mon Jan _2 15:04:05 2006",
+	"d":  "02",
+	"-d": "2",
+	"e":  "_2",
+	"D":  "01/02/06",
+	"F":  "2006-01-02",
+	"H":  "15",
+	"I":  "03",
+	"-I": "3",
+	"j":  "002",
+	"m":  "01",
+	"-m": "1",
+	"M":  "04",
+	"-M": "4",
+	"n":  "\n",
+	"p":  "PM",
+	"R":  "15:04",
+	"S":  "05",
+	"-S": "5",
+	"f":  "000000", // must follow a '.' or ','
+	"L":  "000",    // must follow a '.' or ','
+	"t":  "\t",
+	"T":  "15:04:05",
+	"x":  "01/02/06",
+	"X":  "15:04:05",
+	"y":  "06",
+	"Y":  "2006",
+	"z":  "-0700",
+	":z": "-07:00",
+	"Z":  "MST",
+	"%":  "%",
+}
+
+// strftimeValues are regular expressions matching the text of each strftime directive, for parsing
+var strftimeValues = map[string]string{
+	"a":  `[A-Za-z]+`,
+	"A":  `[A-Za-z]+`,
+	"b":  `[A-Za-z]+`,
+	"h":  `[A-Za-z]+`,
+	"B":  `[A-Za-z]+`,
+	"c":  `[A-Za-z]+ [A-Za-z]+ +\d{1,2} \d{2}:\d{2}:\d{2} \d{4}`,
+	"d":  `\d{2}`,
+	"-d": `\d{1,2}`,
+	"e":  ` ?\d{1,2}`,
+	"D":  `\d{2}/\d{2}/\d{2}`,
+	"F":  `\d{4}-\d{2}-\d{2}`,
+	"H":  `\d{2}`,
+	"I":  `\d{2}`,
+	"-I": `\d{1,2}`,
+	"j":  `\d{3}`,
+	"m":  `\d{2}`,
+	"-m": `\d{1,2}`,
+	"M":  `\d{2}`,
+	"-M": `\d{1,2}`,
+	"p":  `[AaPp][Mm]`,
+	"R":  `\d{2}:\d{2}`,
+	"S":  `\d{2}`,
+	"-S": `\d{1,2}`,
+	"f":  `\d{6}`,
+	"L":  `\d{3}`,
+	"T":  `\d{2}:\d{2}:\d{2}`,
+	"x":  `\d{2}/\d{2}/\d{2}`,
+	"X":  `\d{2}:\d{2}:\d{2}`,
+	"y":  `\d{2}`,
+	"Y":  `\d{4}`,
+	"z":  `[+-]\d{4}`,
+	":z": `[+-]\d{2}:\d{2}`,
+	"Z":  `[A-Za-z]+(?:[+-]\d+)?|[+-]\d{2,4}`,
+}
+
+// layoutPart is a piece of a converted strftime pattern, either literal text or a go layout
+type layoutPart struct {
+	text    string
+	literal bool
+	value   string // a regular expression matching the text of a go layout
+}
+
+// strftimeParts splits a strftime pattern into literal text and go layouts
+func strftimeParts(pattern string) ([]layoutPart, error) {
+	var parts []layoutPart
+	var lit strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' {
+			lit.WriteByte(pattern[i])
+			continue
+		}
+
+		d := ""
+		if i+1 < len(pattern) {
+			d = pattern[i+1 : i+2]
+			if (d == "-" || d == ":") && i+2 < len(pattern) {
+				d = pattern[i+1 : i+3]
+			}
+		}
+		layout, ok := strftimeDirectives[d]
+		value := strftimeValues[d]
+		if !ok {
+			return nil, fmt.Errorf("unsupported strftime directive %%%s in %q", d, pattern)
+		}
+		i += len(d)
+
+		if d == "%" || d == "n" || d == "t" {
+			lit.WriteString(layout)
+			continue
+		}
+		if d == "f" || d == "L" {
+			// go only recognises fractional seconds after a separator, so it becomes part of the layout
+			l := lit.String()
+			if l == "" || (l[len(l)-1] != '.' && l[len(l)-1] != ',') {
+				return nil, fmt.Errorf("strftime directive %%%s must follow '.' or ',' in %q", d, pattern)
+			}
+			layout = l[len(l)-1:] + layout
+			value = regexp.QuoteMeta(l[len(l)-1:]) + value
+			lit.Reset()
+			lit.WriteString(l[:len(l)-1])
+		}
+		if lit.Len() > 0 {
+			parts = append(parts, layoutPart{text: lit.String(), literal: true})
+			lit.Reset()
+		}
+		parts = append(parts, layoutPart{text: layout, value: value})
+	}
+
+	if lit.Len() > 0 {
+		parts = append(parts, layoutPart{text: lit.String(), literal: true})
+	}
+	return parts, nil
+}
+
+// isStrftime returns true if the format looks like a strftime pattern rather than a go layout
+func isStrftime(format string) bool {
+	return strings.Contains(format, "%")
+}
+
+// formatTime formats the timestamp with a go layout, named format or strftime pattern
+func formatTime(format string, t time.Time) (string, error) {
+	name := strings.ToLower(format)
+	if layout, ok := NamedFormats[name]; ok {
+		return t.Format(layout), nil
+	}
+	if unit, ok := unixFormats[name]; ok {
+		return strconv.FormatInt(unixIn(t, unit), 10), nil
+	}
+	if !isStrftime(format) {
+		return t.Format(format), nil
+	}
+
+	parts, err := strftimeParts(format)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, p := range parts {
+		if p.literal {
+			sb.WriteString(p.text)
+		} else {
+			sb.WriteString(t.Format(p.text))
+		}
+	}
+	return sb.String(), nil
+}
+
+// parseTime parses the string with a go layout, named format or strftime pattern
+func parseTime(format string, s string) (time.Time, error) {
+	name := strings.ToLower(format)
+	if layout, ok := NamedFormats[name]; ok {
+		return time.Parse(layout, s)
+	}
+	if unit, ok := unixFormats[name]; ok {
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s timestamp %q", name, s)
+		}
+		return fromUnitsSinceEpoch(n, unit), nil
+	}
+	if !isStrftime(format) {
+		return time.Parse(format, s)
+	}
+
+	parts, err := strftimeParts(format)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// go layouts can't contain literal text that looks like part of a layout (eg digits, or "Mon"),
+	// so the literal text is matched separately, and only the values are parsed with the layout
+	var re strings.Builder
+	var layouts []string
+	re.WriteString("^")
+	for _, p := range parts {
+		if p.literal {
+			re.WriteString(regexp.QuoteMeta(p.text))
+		} else {
+			re.WriteString("(" + p.value + ")")
+			layouts = append(layouts, p.text)
+		}
+	}
+	re.WriteString("$")
+
+	m := regexp.MustCompile(re.String()).FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, fmt.Errorf("parsing time %q: doesn't match the format %q", s, format)
+	}
+	return time.Parse(strings.Join(layouts, "|"), strings.Join(m[1:], "|"))
+}
+
+// detectFormats are the layouts tried, in order, when parsing a time without a format
+var detectFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 -0700 MST", // as output by go
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	time.DateOnly,
+	"2006/01/02",
+	time.RFC1123,
+	time.RFC1123Z,
+	time.RFC850,
+	time.RFC822,
+	time.RFC822Z,
+	time.ANSIC,
+	time.UnixDate,
+	time.RubyDate,
+	"02 Jan 2006",
+	"2 January 2006",
+	"Jan 2, 2006",
+	"January 2, 2006",
+}
+
+// detectTime parses a time in any of the common formats. Integers are taken to be a unix timestamp,
+// with the units (seconds, milliseconds, microseconds or nanoseconds) chosen by the number of digits
+func detectTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		digits := len(strings.TrimLeft(s, "-+"))
+		switch {
+		case digits <= 11:
+			return fromUnitsSinceEpoch(n, time.Second), nil
+		case digits <= 14:
+			return fromUnitsSinceEpoch(n, time.Millisecond), nil
+		case digits <= 17:
+			return fromUnitsSinceEpoch(n, time.Microsecond), nil
+		}
+		return fromUnitsSinceEpoch(n, time.Nanosecond), nil
+	}
+
+	// strip the monotonic clock reading that go includes when printing the current time
+	if i := strings.Index(s, " m="); i > 0 {
+		s = s[:i]
+	}
+
+	for _, f := range detectFormats {
+		if t, err := time.Parse(f, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised time format %q", s)
+}
+
+// unixIn returns the number of units since the unix epoch
+func unixIn(t time.Time, unit time.Duration) int64 {
+	switch unit {
+	case time.Millisecond:
+		return t.UnixMilli()
+	case time.Microsecond:
+		return t.UnixMicro()
+	case time.Nanosecond:
+		return t.UnixNano()
+	}
+	return t.Unix()
+}
+
+// fromUnitsSinceEpoch converts a number of units since the unix epoch to a timestamp
+func fromUnitsSinceEpoch(n int64, unit time.Duration) time.Time {
+	switch unit {
+	case time.Millisecond:
+		return time.UnixMilli(n)
+	case time.Microsecond:
+		return time.UnixMicro(n)
+	case time.Nanosecond:
+		return time.Unix(0, n)
+	}
+	return time.Unix(n, 0)
+}
@@ -0,0 +1,264 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// TestNamedFormats provides unit test coverage for named formats in Now() and TimeFormat()
+func TestNamedFormats(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "rfc3339",
+			Template: `{{ now "rfc3339" }}`,
+			Want:     "2020-08-29T02:14:00Z",
+		},
+		{
+			Name:     "rfc1123",
+			Template: `{{ now "RFC1123" }}`,
+			Want:     "Sat, 29 Aug 2020 02:14:00 UTC",
+		},
+		{
+			Name:     "kitchen",
+			Template: `{{ now "kitchen" }}`,
+			Want:     "2:14AM",
+		},
+		{
+			Name:     "iso8601",
+			Template: `{{ now "iso8601" }}`,
+			Want:     "2020-08-29T02:14:00.133Z",
+		},
+		{
+			Name:     "date",
+			Template: `{{ now "date" }}`,
+			Want:     "2020-08-29",
+		},
+		{
+			Name:     "unix",
+			Template: `{{ now "unix" }}`,
+			Want:     "1598667240",
+		},
+		{
+			Name:     "unixms",
+			Template: `{{ now "unixms" }}`,
+			Want:     "1598667240133",
+		},
+		{
+			Name:     "format time",
+			Template: `{{ timeFormat "datetime" .T }}`,
+			Args:     helper.TestArgs{"T": time.Date(2023, 5, 18, 21, 0, 51, 0, time.UTC)},
+			Want:     "2023-05-18 21:00:51",
+		},
+		{
+			Name:     "format string",
+			Template: `{{ timeFormat "rfc822" "2023-05-18T21:00:51Z" }}`,
+			Want:     "18 May 23 21:00 UTC",
+		},
+		{
+			Name:     "bad time",
+			Template: `{{ timeFormat "rfc822" "soon" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestStrftime provides unit test coverage for strftime patterns in Now() and TimeFormat()
+func TestStrftime(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "date",
+			Template: `{{ now "%Y-%m-%d" }}`,
+			Want:     "2020-08-29",
+		},
+		{
+			Name:     "literal text",
+			Template: `{{ now "day %j of %Y, at %I:%M %p (%Z)" }}`,
+			Want:     "day 242 of 2020, at 02:14 AM (UTC)",
+		},
+		{
+			Name:     "names",
+			Template: `{{ now "%a %A %b %B %h" }}`,
+			Want:     "Sat Saturday Aug August Aug",
+		},
+		{
+			Name:     "unpadded",
+			Template: `{{ timeFormat "%-d/%-m %-I:%-M:%-S" "2023-05-08T09:04:05Z" }}`,
+			Want:     "8/5 9:4:5",
+		},
+		{
+			Name:     "composites",
+			Template: `{{ now "%F %T|%D %R|%x %X|%c" }}`,
+			Want:     "2020-08-29 02:14:00|08/29/20 02:14|08/29/20 02:14:00|Sat Aug 29 02:14:00 2020",
+		},
+		{
+			Name:     "fractional seconds",
+			Template: `{{ now "%H:%M:%S.%L %S,%f" }}`,
+			Want:     "02:14:00.133 00,133700",
+		},
+		{
+			Name:     "zones",
+			Template: `{{ timeFormat "%z %:z" "2023-05-18T21:00:51+10:00" }}`,
+			Want:     "+1000 +10:00",
+		},
+		{
+			Name:     "escapes",
+			Template: `{{ now "%e%%%t%y%n" }}`,
+			Want:     "29%\t20\n",
+		},
+		{
+			Name:     "unsupported directive",
+			Template: `{{ now "%Q" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "trailing percent",
+			Template: `{{ now "%Y%" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "fraction without separator",
+			Template: `{{ now "%S%L" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestTimeParseFormats provides unit test coverage for named formats, strftime patterns and detected formats in TimeParse()
+func TestTimeParseFormats(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "named",
+			Template: `{{ timeParse "rfc1123" "Thu, 18 May 2023 21:00:51 UTC" }}`,
+			Want:     "2023-05-18 21:00:51 +0000 UTC",
+		},
+		{
+			Name:     "unix",
+			Template: `{{ timeParse "unix" "1684407651" | utc }}`,
+			Want:     "2023-05-18 11:00:51 +0000 UTC",
+		},
+		{
+			Name:     "unixms",
+			Template: `{{ timeParse "unixms" "1684407651123" | utc }}`,
+			Want:     "2023-05-18 11:00:51.123 +0000 UTC",
+		},
+		{
+			Name:     "bad unix",
+			Template: `{{ timeParse "unixms" "soon" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "strftime",
+			Template: `{{ timeParse "%d/%m/%Y %H:%M:%S.%L" "18/05/2023 21:00:51.250" }}`,
+			Want:     "2023-05-18 21:00:51.25 +0000 UTC",
+		},
+		{
+			Name:     "strftime with literal digits",
+			Template: `{{ timeParse "build 15 of %d/%m/%Y" "build 15 of 18/05/2023" }}`,
+			Want:     "2023-05-18 00:00:00 +0000 UTC",
+		},
+		{
+			Name:     "strftime with literal layout words",
+			Template: `{{ timeParse "Mon Jan PM report %F" "Mon Jan PM report 2023-05-18" }}`,
+			Want:     "2023-05-18 00:00:00 +0000 UTC",
+		},
+		{
+			Name:     "strftime with padded and named fields",
+			Template: `{{ timeParse "%a %b %e %-I:%M %p %Z %Y" "Thu May  8 9:00 PM UTC 2023" }}`,
+			Want:     "2023-05-08 21:00:00 +0000 UTC",
+		},
+		{
+			Name:     "strftime c",
+			Template: `{{ timeParse "%c" "Thu May 18 21:00:51 2023" }}`,
+			Want:     "2023-05-18 21:00:51 +0000 UTC",
+		},
+		{
+			Name:     "strftime with a literal that doesn't match",
+			Template: `{{ timeParse "week 1: %F" "week 2: 2023-05-18" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "strftime with an invalid value",
+			Template: `{{ timeParse "%d/%m/%Y" "18/13/2023" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad strftime",
+			Template: `{{ timeParse "%d/%m/%Q" "18/05/2023" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "detect rfc3339",
+			Template: `{{ timeParse "2023-05-18T21:00:51+10:00" | utc }}`,
+			Want:     "2023-05-18 11:00:51 +0000 UTC",
+		},
+		{
+			Name:     "detect without zone",
+			Template: `{{ timeParse "2023-05-18T21:00:51" }}`,
+			Want:     "2023-05-18 21:00:51 +0000 UTC",
+		},
+		{
+			Name:     "detect go output",
+			Template: `{{ timeParse "2023-05-18 21:00:51.5 +0000 UTC m=+0.000012" }}`,
+			Want:     "2023-05-18 21:00:51.5 +0000 UTC",
+		},
+		{
+			Name:     "detect date",
+			Template: `{{ timeParse "2023-05-18" }}`,
+			Want:     "2023-05-18 00:00:00 +0000 UTC",
+		},
+		{
+			Name:     "detect rfc1123",
+			Template: `{{ timeParse "Thu, 18 May 2023 21:00:51 GMT" | timeFormat "rfc3339" }}`,
+			Want:     "2023-05-18T21:00:51Z",
+		},
+		{
+			Name:     "detect written date",
+			Template: `{{ timeParse "May 18, 2023" }}`,
+			Want:     "2023-05-18 00:00:00 +0000 UTC",
+		},
+		{
+			Name:     "detect unix seconds",
+			Template: `{{ timeParse "1684407651" | timeToUnix }}`,
+			Want:     "1684407651",
+		},
+		{
+			Name:     "detect unix milliseconds",
+			Template: `{{ timeParse "1684407651123" | timeToUnixMS }}`,
+			Want:     "1684407651123",
+		},
+		{
+			Name:     "detect unix microseconds",
+			Template: `{{ timeParse "1684407651123456" | timeToUnixNS }}`,
+			Want:     "1684407651123456000",
+		},
+		{
+			Name:     "detect unix nanoseconds",
+			Template: `{{ timeParse "1684407651123456789" | timeToUnixNS }}`,
+			Want:     "1684407651123456789",
+		},
+		{
+			Name:     "unrecognised",
+			Template: `{{ timeParse "the day after tomorrow" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "too many args",
+			Template: `{{ timeParse "date" "2023-05-18" "2023-05-19" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
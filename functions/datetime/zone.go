@@ -0,0 +1,103 @@
+package datetime
+
+import (
+	"archive/zip"
+	"bytes"
+	_ "embed" // for the time zone database
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// zoneInfo is the IANA time zone database (a copy of $GOROOT/lib/time/zoneinfo.zip), embedded so that conversions
+// don't depend on the host, which time.LoadLocation would otherwise use in preference
+//
+//go:embed zoneinfo.zip
+var zoneInfo []byte
+
+var (
+	zoneFiles     map[string]*zip.File
+	zoneFilesErr  error
+	zoneFilesOnce sync.Once
+	zoneCache     sync.Map // of zone name to *time.Location
+)
+
+// loadZone returns the named location from the embedded time zone database
+func loadZone(name string) (*time.Location, error) {
+	switch name {
+	case "", "UTC":
+		return time.UTC, nil
+	case "Local":
+		return time.Local, nil
+	}
+	if loc, ok := zoneCache.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	zoneFilesOnce.Do(func() {
+		var r *zip.Reader
+		r, zoneFilesErr = zip.NewReader(bytes.NewReader(zoneInfo), int64(len(zoneInfo)))
+		if zoneFilesErr != nil {
+			return
+		}
+		zoneFiles = make(map[string]*zip.File, len(r.File))
+		for _, f := range r.File {
+			zoneFiles[f.Name] = f
+		}
+	})
+	if zoneFilesErr != nil {
+		return nil, fmt.Errorf("reading the time zone database: %w", zoneFilesErr)
+	}
+
+	f, ok := zoneFiles[name]
+	if !ok {
+		return nil, fmt.Errorf("no such time zone")
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocationFromTZData(name, data)
+	if err != nil {
+		return nil, err
+	}
+	zoneCache.Store(name, loc)
+	return loc, nil
+}
+
+// InZone converts the given timestamp (or the current time) to the named IANA time zone, eg "Australia/Sydney"
+func InZone(zone string, ts ...any) (time.Time, error) {
+	loc, err := loadZone(zone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unknown time zone %q: %w", zone, err)
+	}
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
+
+// UTC converts the given timestamp (or the current time) to UTC
+func UTC(ts ...any) (time.Time, error) {
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+// LocalTime converts the given timestamp (or the current time) to the local time zone of the host
+func LocalTime(ts ...any) (time.Time, error) {
+	t, err := timeOrNow(ts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Local(), nil
+}
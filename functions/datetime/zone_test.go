@@ -0,0 +1,130 @@
+package datetime
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInZone provides unit test coverage for InZone()
+func TestInZone(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "now",
+			Template: `{{ inZone "Australia/Sydney" }}`,
+			Want:     "2020-08-29 12:14:00.1337 +1000 AEST",
+		},
+		{
+			Name:     "daylight savings",
+			Template: `{{ inZone "Australia/Sydney" "2023-01-18T21:00:51Z" }}`,
+			Want:     "2023-01-19 08:00:51 +1100 AEDT",
+		},
+		{
+			Name:     "from another zone",
+			Template: `{{ inZone "America/New_York" "2023-05-18T21:00:51+10:00" | timeFormat "2006-01-02T15:04:05Z07:00" }}`,
+			Want:     "2023-05-18T07:00:51-04:00",
+		},
+		{
+			Name:     "UTC",
+			Template: `{{ inZone "UTC" "2023-05-18T21:00:51+10:00" }}`,
+			Want:     "2023-05-18 11:00:51 +0000 UTC",
+		},
+		{
+			Name:     "unknown zone",
+			Template: `{{ inZone "Nowhere/Special" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad time",
+			Template: `{{ inZone "UTC" "today" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestInZoneIgnoresHost ensures that time zones come from the embedded database, and not the host's.
+// As the time package only reads ZONEINFO once, the checks are run in a new process
+func TestInZoneIgnoresHost(t *testing.T) {
+	if os.Getenv("TPLR_ZONE_TEST") != "" {
+		got, err := InZone("Australia/Sydney", "2023-01-18T21:00:51Z")
+		require.NoError(t, err)
+		assert.Equal(t, "2023-01-19 08:00:51 +1100 AEDT", got.String())
+		return
+	}
+
+	run := func(zoneInfo string) {
+		t.Helper()
+		cmd := exec.Command(os.Args[0], "-test.run=^TestInZoneIgnoresHost$")
+		cmd.Env = append(os.Environ(), "TPLR_ZONE_TEST=1", "ZONEINFO="+zoneInfo)
+		out, err := cmd.CombinedOutput()
+		assert.NoError(t, err, "with ZONEINFO=%s: %s", zoneInfo, out)
+	}
+
+	run(filepath.Join(t.TempDir(), "missing"))
+
+	// a host database where Sydney is UTC
+	z, err := zip.NewReader(bytes.NewReader(zoneInfo), int64(len(zoneInfo)))
+	require.NoError(t, err)
+	f, err := z.Open("Etc/UTC")
+	require.NoError(t, err)
+	utc, err := io.ReadAll(f)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "Australia"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Australia", "Sydney"), utc, 0o644))
+	run(dir)
+}
+
+// TestUTC provides unit test coverage for UTC()
+func TestUTC(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "now",
+			Template: `{{ utc }}`,
+			Want:     "2020-08-29 02:14:00.1337 +0000 UTC",
+		},
+		{
+			Name:     "from another zone",
+			Template: `{{ utc "2023-05-18T21:00:51+10:00" }}`,
+			Want:     "2023-05-18 11:00:51 +0000 UTC",
+		},
+		{
+			Name:     "bad time",
+			Template: `{{ utc "today" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestLocalTime provides unit test coverage for LocalTime()
+func TestLocalTime(t *testing.T) {
+	got, err := LocalTime("2023-05-18T21:00:51+10:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Local, got.Location())
+	assert.Equal(t, int64(1684407651), got.Unix())
+
+	got, err = LocalTime()
+	require.NoError(t, err)
+	assert.True(t, got.Equal(helper.Now()))
+
+	_, err = LocalTime("today")
+	assert.Error(t, err)
+}
@@ -0,0 +1,276 @@
+// Package dict provides methods for creating and manipulating dictionaries (maps) in templates
+package dict
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"text/template"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// Functions operate on dictionaries of key/value pairs
+func Functions() template.FuncMap {
+	return template.FuncMap{
+		"dict":           Dict,
+		"get":            Get,
+		"set":            Set,
+		"unset":          Unset,
+		"hasKey":         HasKey,
+		"keys":           Keys,
+		"values":         Values,
+		"pick":           Pick,
+		"omit":           Omit,
+		"merge":          Merge,
+		"mergeOverwrite": MergeOverwrite,
+		"deepCopy":       DeepCopy,
+	}
+}
+
+// Dict creates a new dictionary from the given list of key, value pairs
+func Dict(pairs ...any) (map[string]any, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("dict requires an even number of arguments, got %d", len(pairs))
+	}
+
+	d := make(map[string]any, len(pairs)/2)
+	for c := 0; c < len(pairs); c += 2 {
+		d[fmt.Sprintf("%v", pairs[c])] = pairs[c+1]
+	}
+	return d, nil
+}
+
+// Get returns the value stored under key in the dictionary, or the default (if supplied) when the key isn't present
+func Get(key string, d any, def ...any) (any, error) {
+	m, err := helper.AsMap(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := m[key]; ok {
+		return v, nil
+	}
+	if len(def) > 0 {
+		return def[0], nil
+	}
+	return nil, nil
+}
+
+// Set returns a copy of the dictionary with key set to value
+func Set(key string, value any, d any) (map[string]any, error) {
+	m, err := helper.AsMap(d)
+	if err != nil {
+		return nil, err
+	}
+
+	res := helper.Combine(m)
+	res[key] = value
+	return res, nil
+}
+
+// Unset returns a copy of the dictionary with key removed
+func Unset(key string, d any) (map[string]any, error) {
+	m, err := helper.AsMap(d)
+	if err != nil {
+		return nil, err
+	}
+
+	res := helper.Combine(m)
+	delete(res, key)
+	return res, nil
+}
+
+// HasKey returns true if the dictionary contains the given key
+func HasKey(key string, d any) (bool, error) {
+	m, err := helper.AsMap(d)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := m[key]
+	return ok, nil
+}
+
+// Keys returns the keys of the dictionary, in sorted order
+func Keys(d any) ([]string, error) {
+	m, err := helper.AsMap(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedKeys(m), nil
+}
+
+// Values returns the values of the dictionary, ordered by their (sorted) keys
+func Values(d any) ([]any, error) {
+	m, err := helper.AsMap(d)
+	if err != nil {
+		return nil, err
+	}
+
+	k := sortedKeys(m)
+	res := make([]any, len(k))
+	for i, key := range k {
+		res[i] = m[key]
+	}
+	return res, nil
+}
+
+// Pick returns a new dictionary containing only the given keys.
+// The dictionary is the last argument, after the keys, so that it can be piped in
+func Pick(args ...any) (map[string]any, error) {
+	keys, m, err := keysAndDict("pick", args)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]any, len(keys))
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+// Omit returns a new dictionary containing everything except the given keys.
+// The dictionary is the last argument, after the keys, so that it can be piped in
+func Omit(args ...any) (map[string]any, error) {
+	keys, m, err := keysAndDict("omit", args)
+	if err != nil {
+		return nil, err
+	}
+
+	res := helper.Combine(m)
+	for _, k := range keys {
+		delete(res, k)
+	}
+	return res, nil
+}
+
+// keysAndDict splits the arguments of a function into the keys, and the dictionary that follows them
+func keysAndDict(name string, args []any) ([]string, map[string]any, error) {
+	if len(args) == 0 {
+		return nil, nil, fmt.Errorf("%s requires a dictionary", name)
+	}
+	m, err := helper.AsMap(args[len(args)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([]string, len(args)-1)
+	for i, k := range args[:len(args)-1] {
+		keys[i] = fmt.Sprintf("%v", k)
+	}
+	return keys, m, nil
+}
+
+// Merge combines the given dictionaries into a new one, recursively merging nested dictionaries.
+// Where keys collide, values from earlier dictionaries take precedence
+func Merge(dicts ...any) (map[string]any, error) {
+	return merge(false, dicts)
+}
+
+// MergeOverwrite combines the given dictionaries into a new one, recursively merging nested dictionaries.
+// Where keys collide, values from later dictionaries take precedence
+func MergeOverwrite(dicts ...any) (map[string]any, error) {
+	return merge(true, dicts)
+}
+
+// DeepCopy returns a copy of the given value, recursively copying any maps, lists and pointers it contains
+func DeepCopy(v any) any {
+	if v == nil {
+		return nil
+	}
+	return deepCopy(reflect.ValueOf(v)).Interface()
+}
+
+// merge is the helper for Merge and MergeOverwrite
+func merge(overwrite bool, dicts []any) (map[string]any, error) {
+	res := make(map[string]any)
+	for _, d := range dicts {
+		m, err := helper.AsMap(d)
+		if err != nil {
+			return nil, err
+		}
+		res = mergeInto(res, m, overwrite)
+	}
+	return res, nil
+}
+
+// mergeInto copies src into dst, returning dst
+func mergeInto(dst, src map[string]any, overwrite bool) map[string]any {
+	for k, v := range src {
+		existing, exists := dst[k]
+		if !exists {
+			dst[k] = DeepCopy(v)
+			continue
+		}
+
+		dm, errD := helper.AsMap(existing)
+		sm, errS := helper.AsMap(v)
+		if errD == nil && errS == nil {
+			dst[k] = mergeInto(helper.Combine(dm), sm, overwrite)
+		} else if overwrite {
+			dst[k] = DeepCopy(v)
+		}
+	}
+	return dst
+}
+
+// deepCopy recursively copies a reflected value
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		i := v.MapRange()
+		for i.Next() {
+			c.SetMapIndex(i.Key(), deepCopy(i.Value()))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	}
+	return v
+}
+
+// sortedKeys returns the keys of the map in sorted order
+func sortedKeys(m map[string]any) []string {
+	k := make([]string, 0, len(m))
+	for key := range m {
+		k = append(k, key)
+	}
+	sort.Strings(k)
+	return k
+}
@@ -0,0 +1,458 @@
+package dict
+
+import (
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFunctions provides unit test coverage for Functions
+func TestFunctions(t *testing.T) {
+	fn := Functions()
+	assert.Len(t, fn, 12, "weakly ensuring functions haven't been added/removed without updating tests")
+}
+
+// TestDict provides unit test coverage for Dict()
+func TestDict(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "empty",
+			Template: `{{ dict }}`,
+			Want:     "map[]",
+		},
+		{
+			Name:     "pairs",
+			Template: `{{ dict "a" 1 "b" .B }}`,
+			Args: helper.TestArgs{
+				"B": "two",
+			},
+			Want: "map[a:1 b:two]",
+		},
+		{
+			Name:     "non-string key",
+			Template: `{{ dict 5 "five" }}`,
+			Want:     "map[5:five]",
+		},
+		{
+			Name:     "odd number of args",
+			Template: `{{ dict "a" 1 "b" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestGet provides unit test coverage for Get()
+func TestGet(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "present",
+			Template: `{{ get "a" .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1},
+			},
+			Want: "1",
+		},
+		{
+			Name:     "missing",
+			Template: `{{ get "b" .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1},
+			},
+			Want: "<no value>",
+		},
+		{
+			Name:     "missing with default",
+			Template: `{{ get "b" .D "nope" }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1},
+			},
+			Want: "nope",
+		},
+		{
+			Name:     "typed map",
+			Template: `{{ .D | get "a" }}`,
+			Args: helper.TestArgs{
+				"D": map[string]string{"a": "x"},
+			},
+			Want: "x",
+		},
+		{
+			Name:     "not a map",
+			Template: `{{ get "a" .D }}`,
+			Args: helper.TestArgs{
+				"D": "string",
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestSet provides unit test coverage for Set()
+func TestSet(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "new key",
+			Template: `{{ set "b" 2 .D }} {{ .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1},
+			},
+			Want: "map[a:1 b:2] map[a:1]",
+		},
+		{
+			Name:     "replace key",
+			Template: `{{ set "a" 2 .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1},
+			},
+			Want: "map[a:2]",
+		},
+		{
+			Name:     "not a map",
+			Template: `{{ set "a" 2 .D }}`,
+			Args: helper.TestArgs{
+				"D": nil,
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestUnset provides unit test coverage for Unset()
+func TestUnset(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "present",
+			Template: `{{ unset "a" .D }} {{ .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1, "b": 2},
+			},
+			Want: "map[b:2] map[a:1 b:2]",
+		},
+		{
+			Name:     "missing",
+			Template: `{{ unset "c" .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1},
+			},
+			Want: "map[a:1]",
+		},
+		{
+			Name:     "not a map",
+			Template: `{{ unset "a" .D }}`,
+			Args: helper.TestArgs{
+				"D": 5,
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestHasKey provides unit test coverage for HasKey()
+func TestHasKey(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "present",
+			Template: `{{ hasKey "a" .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": nil},
+			},
+			Want: "true",
+		},
+		{
+			Name:     "missing",
+			Template: `{{ hasKey "b" .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1},
+			},
+			Want: "false",
+		},
+		{
+			Name:     "not a map",
+			Template: `{{ hasKey "a" .D }}`,
+			Args: helper.TestArgs{
+				"D": []string{"a"},
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestKeys provides unit test coverage for Keys()
+func TestKeys(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "empty",
+			Template: `{{ keys .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{},
+			},
+			Want: "[]",
+		},
+		{
+			Name:     "sorted",
+			Template: `{{ keys .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"c": 1, "a": 2, "b": 3},
+			},
+			Want: "[a b c]",
+		},
+		{
+			Name:     "not a map",
+			Template: `{{ keys .D }}`,
+			Args: helper.TestArgs{
+				"D": "abc",
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestValues provides unit test coverage for Values()
+func TestValues(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "ordered by key",
+			Template: `{{ values .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"c": 1, "a": 2, "b": 3},
+			},
+			Want: "[2 3 1]",
+		},
+		{
+			Name:     "not a map",
+			Template: `{{ values .D }}`,
+			Args: helper.TestArgs{
+				"D": "abc",
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestPick provides unit test coverage for Pick()
+func TestPick(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "some",
+			Template: `{{ pick "a" "c" "z" .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1, "b": 2, "c": 3},
+			},
+			Want: "map[a:1 c:3]",
+		},
+		{
+			Name:     "none",
+			Template: `{{ pick .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1},
+			},
+			Want: "map[]",
+		},
+		{
+			Name:     "piped",
+			Template: `{{ .D | pick "b" }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1, "b": 2},
+			},
+			Want: "map[b:2]",
+		},
+		{
+			Name:     "not a map",
+			Template: `{{ pick "a" .D }}`,
+			Args: helper.TestArgs{
+				"D": 1,
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "no dictionary",
+			Template: `{{ pick }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestOmit provides unit test coverage for Omit()
+func TestOmit(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "some",
+			Template: `{{ omit "a" "c" "z" .D }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1, "b": 2, "c": 3},
+			},
+			Want: "map[b:2]",
+		},
+		{
+			Name:     "piped",
+			Template: `{{ .D | omit "a" }}`,
+			Args: helper.TestArgs{
+				"D": map[string]any{"a": 1, "b": 2},
+			},
+			Want: "map[b:2]",
+		},
+		{
+			Name:     "not a map",
+			Template: `{{ omit "a" .D }}`,
+			Args: helper.TestArgs{
+				"D": 1,
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestMerge provides unit test coverage for Merge()
+func TestMerge(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "none",
+			Template: `{{ merge }}`,
+			Want:     "map[]",
+		},
+		{
+			Name:     "first wins",
+			Template: `{{ merge .A .B }}`,
+			Args: helper.TestArgs{
+				"A": map[string]any{"a": 1, "b": 2},
+				"B": map[string]any{"b": 3, "c": 4},
+			},
+			Want: "map[a:1 b:2 c:4]",
+		},
+		{
+			Name:     "nested",
+			Template: `{{ merge .A .B }}`,
+			Args: helper.TestArgs{
+				"A": map[string]any{"n": map[string]any{"x": 1}},
+				"B": map[string]any{"n": map[string]any{"x": 2, "y": 3}},
+			},
+			Want: "map[n:map[x:1 y:3]]",
+		},
+		{
+			Name:     "not a map",
+			Template: `{{ merge .A .B }}`,
+			Args: helper.TestArgs{
+				"A": map[string]any{"a": 1},
+				"B": "b",
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestMergeOverwrite provides unit test coverage for MergeOverwrite()
+func TestMergeOverwrite(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "last wins",
+			Template: `{{ mergeOverwrite .A .B }}`,
+			Args: helper.TestArgs{
+				"A": map[string]any{"a": 1, "b": 2},
+				"B": map[string]any{"b": 3, "c": 4},
+			},
+			Want: "map[a:1 b:3 c:4]",
+		},
+		{
+			Name:     "nested",
+			Template: `{{ mergeOverwrite .A .B }} {{ .A }}`,
+			Args: helper.TestArgs{
+				"A": map[string]any{"n": map[string]any{"x": 1, "z": 0}},
+				"B": map[string]any{"n": map[string]any{"x": 2, "y": 3}},
+			},
+			Want: "map[n:map[x:2 y:3 z:0]] map[n:map[x:1 z:0]]",
+		},
+		{
+			Name:     "map replaced by value",
+			Template: `{{ mergeOverwrite .A .B }}`,
+			Args: helper.TestArgs{
+				"A": map[string]any{"n": map[string]any{"x": 1}},
+				"B": map[string]any{"n": "flat"},
+			},
+			Want: "map[n:flat]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestDeepCopy provides unit test coverage for DeepCopy()
+func TestDeepCopy(t *testing.T) {
+	tests := []struct {
+		Name string
+		Arg  any
+	}{
+		{
+			Name: "nil",
+			Arg:  nil,
+		},
+		{
+			Name: "scalar",
+			Arg:  5,
+		},
+		{
+			Name: "nested",
+			Arg: map[string]any{
+				"list": []any{1, "two", map[string]any{"three": 3.0}},
+				"map":  map[string]string{"a": "b"},
+				"ptr":  helper.PtrTo(4),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			got := DeepCopy(tt.Arg)
+			assert.Equal(t, tt.Arg, got)
+		})
+	}
+
+	t.Run("independent", func(t *testing.T) {
+		orig := map[string]any{"list": []any{map[string]any{"a": 1}}}
+		cp := DeepCopy(orig).(map[string]any)
+		cp["list"].([]any)[0].(map[string]any)["a"] = 2
+		assert.Equal(t, 1, orig["list"].([]any)[0].(map[string]any)["a"])
+	})
+}
@@ -12,3 +12,36 @@ func FromBase64(s string) (string, error) {
 	decoded, err := base64.StdEncoding.DecodeString(s)
 	return string(decoded), err
 }
+
+// ToBase64Raw converts the given string to a base64 encoding without padding
+func ToBase64Raw(s string) string {
+	return base64.RawStdEncoding.EncodeToString([]byte(s))
+}
+
+// FromBase64Raw decodes the given unpadded base64 string to plain
+func FromBase64Raw(s string) (string, error) {
+	decoded, err := base64.RawStdEncoding.DecodeString(s)
+	return string(decoded), err
+}
+
+// ToBase64URL converts the given string to the URL and filename safe base64 encoding
+func ToBase64URL(s string) string {
+	return base64.URLEncoding.EncodeToString([]byte(s))
+}
+
+// FromBase64URL decodes the given URL and filename safe base64 string to plain
+func FromBase64URL(s string) (string, error) {
+	decoded, err := base64.URLEncoding.DecodeString(s)
+	return string(decoded), err
+}
+
+// ToBase64URLRaw converts the given string to the URL and filename safe base64 encoding without padding
+func ToBase64URLRaw(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// FromBase64URLRaw decodes the given unpadded URL and filename safe base64 string to plain
+func FromBase64URLRaw(s string) (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(s)
+	return string(decoded), err
+}
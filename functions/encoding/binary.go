@@ -0,0 +1,92 @@
+package encoding
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/ascii85"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ToHex converts the given string to hexadecimal, with two (lower case) digits per byte
+func ToHex(s string) string {
+	return hex.EncodeToString([]byte(s))
+}
+
+// FromHex decodes the given hexadecimal string to plain
+func FromHex(s string) (string, error) {
+	decoded, err := hex.DecodeString(s)
+	return string(decoded), err
+}
+
+// ToBase32 converts the given string to a base32 encoding
+func ToBase32(s string) string {
+	return base32.StdEncoding.EncodeToString([]byte(s))
+}
+
+// FromBase32 decodes the given base32 string to plain
+func FromBase32(s string) (string, error) {
+	decoded, err := base32.StdEncoding.DecodeString(s)
+	return string(decoded), err
+}
+
+// ToBase32Raw converts the given string to a base32 encoding without padding
+func ToBase32Raw(s string) string {
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(s))
+}
+
+// FromBase32Raw decodes the given unpadded base32 string to plain
+func FromBase32Raw(s string) (string, error) {
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s)
+	return string(decoded), err
+}
+
+// ToAscii85 converts the given string to an ascii85 encoding (without the <~ ~> delimiters)
+func ToAscii85(s string) string {
+	buf := make([]byte, ascii85.MaxEncodedLen(len(s)))
+	n := ascii85.Encode(buf, []byte(s))
+	return string(buf[:n])
+}
+
+// FromAscii85 decodes the given ascii85 string, optionally surrounded by <~ ~> delimiters, to plain
+func FromAscii85(s string) (string, error) {
+	s = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(s), "<~"), "~>")
+	buf := make([]byte, 4*len(s))
+	n, _, err := ascii85.Decode(buf, []byte(s), true)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode ascii85: %w", err)
+	}
+	return string(buf[:n]), nil
+}
+
+// Gzip compresses the given string. The output is binary, so is usually encoded further, eg with toBase64.
+// No timestamp or file name is included, so the same input always gives the same output
+func Gzip(s string) (string, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Gunzip decompresses the given gzip compressed string
+func Gunzip(s string) (string, error) {
+	r, err := gzip.NewReader(strings.NewReader(s))
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress: %w", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress: %w", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,200 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBinaryEncodings provides unit test coverage for the hex, base32, base64 variant and ascii85 encodings
+func TestBinaryEncodings(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "hex",
+			Template: `{{ toHex "Hi!\n" }}`,
+			Want:     "4869210a",
+		},
+		{
+			Name:     "from hex",
+			Template: `{{ fromHex "4869210A" }}`,
+			Want:     "Hi!\n",
+		},
+		{
+			Name:     "bad hex",
+			Template: `{{ fromHex "486" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "base32",
+			Template: `{{ toBase32 "foobar" }}`,
+			Want:     "MZXW6YTBOI======",
+		},
+		{
+			Name:     "from base32",
+			Template: `{{ fromBase32 "MZXW6YTBOI======" }}`,
+			Want:     "foobar",
+		},
+		{
+			Name:     "bad base32",
+			Template: `{{ fromBase32 "MZXW6YTBOI" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "raw base32",
+			Template: `{{ toBase32Raw "foobar" }}`,
+			Want:     "MZXW6YTBOI",
+		},
+		{
+			Name:     "from raw base32",
+			Template: `{{ fromBase32Raw "MZXW6YTBOI" }}`,
+			Want:     "foobar",
+		},
+		{
+			Name:     "raw base64",
+			Template: `{{ toBase64Raw "A basic string" }}`,
+			Want:     "QSBiYXNpYyBzdHJpbmc",
+		},
+		{
+			Name:     "from raw base64",
+			Template: `{{ fromBase64Raw "QSBiYXNpYyBzdHJpbmc" }}`,
+			Want:     "A basic string",
+		},
+		{
+			Name:     "bad raw base64",
+			Template: `{{ fromBase64Raw "QSBiYXNpYyBzdHJpbmc=" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "base64 url",
+			Template: `{{ toBase64URL "?>?>" }}`,
+			Want:     "Pz4_Pg==",
+		},
+		{
+			Name:     "from base64 url",
+			Template: `{{ fromBase64URL "Pz4_Pg==" }}`,
+			Want:     "?>?>",
+		},
+		{
+			Name:     "bad base64 url",
+			Template: `{{ fromBase64URL "Pz4/Pg==" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "raw base64 url",
+			Template: `{{ toBase64URLRaw "?>?>" }}`,
+			Want:     "Pz4_Pg",
+		},
+		{
+			Name:     "from raw base64 url",
+			Template: `{{ fromBase64URLRaw "Pz4_Pg" }}`,
+			Want:     "?>?>",
+		},
+		{
+			Name:     "ascii85",
+			Template: `{{ toAscii85 "Man is" }}`,
+			Want:     "9jqo^Bla",
+		},
+		{
+			Name:     "ascii85 of zeros",
+			Template: `{{ toAscii85 .S }}`,
+			Args:     helper.TestArgs{"S": "\x00\x00\x00\x00"},
+			Want:     "z",
+		},
+		{
+			Name:     "from ascii85",
+			Template: `{{ fromAscii85 "9jqo^Bla" }}`,
+			Want:     "Man is",
+		},
+		{
+			Name:     "from delimited ascii85",
+			Template: `{{ fromAscii85 "<~9jqo^Bla~>" }}`,
+			Want:     "Man is",
+		},
+		{
+			Name:     "bad ascii85",
+			Template: `{{ fromAscii85 "9jqo{" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestGzip provides unit test coverage for Gzip() and Gunzip()
+func TestGzip(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "header without timestamp",
+			Template: `{{ gzip "hello" | toBase64 | printf "%.12s" }}`,
+			Want:     "H4sIAAAAAAAA",
+		},
+		{
+			Name:     "deterministic",
+			Template: `{{ eq (gzip "hello") (gzip "hello") }}`,
+			Want:     "true",
+		},
+		{
+			Name:     "round trip",
+			Template: `{{ gzip .S | toBase64 | fromBase64 | gunzip }}`,
+			Args:     helper.TestArgs{"S": "some configuration\nto embed"},
+			Want:     "some configuration\nto embed",
+		},
+		{
+			Name:     "not compressed",
+			Template: `{{ gunzip "hello" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "truncated",
+			Template: `{{ gzip "hello" | printf "%.20s" | gunzip }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestBinaryRoundTrip checks that each of the encodings decodes back to any input, including binary data
+func TestBinaryRoundTrip(t *testing.T) {
+	all := make([]byte, 256)
+	for i := range all {
+		all[i] = byte(i)
+	}
+	inputs := []string{"", "a", "ab", "abc", "abcd", "\x00\x00\x00\x00\x00", string(all)}
+
+	pairs := map[string]struct {
+		to   func(string) string
+		from func(string) (string, error)
+	}{
+		"hex":           {ToHex, FromHex},
+		"base32":        {ToBase32, FromBase32},
+		"base32 raw":    {ToBase32Raw, FromBase32Raw},
+		"base64":        {ToBase64, FromBase64},
+		"base64 raw":    {ToBase64Raw, FromBase64Raw},
+		"base64url":     {ToBase64URL, FromBase64URL},
+		"base64url raw": {ToBase64URLRaw, FromBase64URLRaw},
+		"ascii85":       {ToAscii85, FromAscii85},
+		"gzip": {
+			func(s string) string {
+				z, err := Gzip(s)
+				require.NoError(t, err)
+				return z
+			},
+			Gunzip,
+		},
+	}
+
+	for name, p := range pairs {
+		for _, in := range inputs {
+			got, err := p.from(p.to(in))
+			require.NoError(t, err, name)
+			assert.Equal(t, in, got, name)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package encoding
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ToCSV returns the given list as csv. A list of dictionaries is written with a header line of all
+// of their keys in sorted order, while a list of lists is written as is.
+// Values that are themselves lists or dictionaries are written as json
+func ToCSV(val any) (string, error) {
+	l, ok := plain(val).([]any)
+	if !ok {
+		return "", fmt.Errorf("csv must be a list of rows, not %T", val)
+	}
+
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+
+	var header []string
+	if isTableList(l) {
+		seen := map[string]any{}
+		for _, r := range l {
+			for k := range r.(map[string]any) {
+				seen[k] = true
+			}
+		}
+		header = sortedKeys(seen)
+		if err := w.Write(header); err != nil {
+			return "", err
+		}
+	}
+
+	for i, r := range l {
+		var cells []any
+		switch t := r.(type) {
+		case map[string]any:
+			if header == nil {
+				return "", fmt.Errorf("csv row %d is a dictionary, but not all rows are", i+1)
+			}
+			cells = make([]any, len(header))
+			for c, h := range header {
+				cells[c] = t[h]
+			}
+		case []any:
+			cells = t
+		default:
+			return "", fmt.Errorf("csv row %d must be a list or dictionary, not %T", i+1, r)
+		}
+
+		record := make([]string, len(cells))
+		for c, v := range cells {
+			s, err := csvCell(v)
+			if err != nil {
+				return "", fmt.Errorf("csv row %d: %w", i+1, err)
+			}
+			record[c] = s
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	return sb.String(), w.Error()
+}
+
+func csvCell(v any) (string, error) {
+	switch v.(type) {
+	case []any, map[string]any:
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+	return scalarString(v), nil
+}
@@ -0,0 +1,80 @@
+package encoding
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// FromJSON decodes the given json string. Objects become map[string]any, arrays []any and numbers float64
+func FromJSON(s string) (any, error) {
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, fmt.Errorf("failed to parse json: %w", err)
+	}
+	return v, nil
+}
+
+// FromYAML decodes the given yaml string. Mappings become map[string]any and sequences []any
+func FromYAML(s string) (any, error) {
+	var v any
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return nil, fmt.Errorf("failed to parse yaml: %w", err)
+	}
+	return normaliseYAML(v), nil
+}
+
+// normaliseYAML converts the map[any]any used by yaml to map[string]any, so that it works like other decoded data
+func normaliseYAML(v any) any {
+	switch t := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(t))
+		for k, e := range t {
+			m[fmt.Sprint(k)] = normaliseYAML(e)
+		}
+		return m
+	case []any:
+		l := make([]any, len(t))
+		for i, e := range t {
+			l[i] = normaliseYAML(e)
+		}
+		return l
+	}
+	return v
+}
+
+// FromCSV decodes the given csv string into a list of rows, each a map of the values keyed by the column headers
+// in the first line
+func FromCSV(s string) ([]any, error) {
+	r := csv.NewReader(strings.NewReader(s))
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return []any{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse csv: %w", err)
+	}
+
+	rows := []any{}
+	for {
+		record, errR := r.Read()
+		if errors.Is(errR, io.EOF) {
+			return rows, nil
+		}
+		if errR != nil {
+			return nil, fmt.Errorf("failed to parse csv: %w", errR)
+		}
+
+		row := make(map[string]any, len(header))
+		for i, h := range header {
+			row[h] = record[i]
+		}
+		rows = append(rows, row)
+	}
+}
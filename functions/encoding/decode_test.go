@@ -0,0 +1,201 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFromJSON provides unit test coverage for FromJSON()
+func TestFromJSON(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "object",
+			Template: `{{ $o := fromJSON .json }}{{ $o.name }} {{ index $o.tags 1 }} {{ $o.size }}`,
+			Args: helper.TestArgs{
+				"json": `{"name": "widget", "tags": ["a", "b"], "size": 3}`,
+			},
+			Want: "widget b 3",
+		},
+		{
+			Name:     "list",
+			Template: `{{ range fromJSON .json }}{{ . }};{{ end }}`,
+			Args: helper.TestArgs{
+				"json": `[1, "two", true, null]`,
+			},
+			Want: "1;two;true;<no value>;",
+		},
+		{
+			Name:     "bad json",
+			Template: `{{ fromJSON .json }}`,
+			Args: helper.TestArgs{
+				"json": `{"name": }`,
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestFromYAML provides unit test coverage for FromYAML()
+func TestFromYAML(t *testing.T) {
+	got, err := FromYAML("name: widget\nsizes:\n  - 1\n  - 2\nnested:\n  1: one\n  list:\n    - key: value\n")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":  "widget",
+		"sizes": []any{1, 2},
+		"nested": map[string]any{
+			"1":    "one",
+			"list": []any{map[string]any{"key": "value"}},
+		},
+	}, got)
+
+	tests := []helper.TestSet{
+		{
+			Name:     "walk structure",
+			Template: `{{ with fromYAML .yaml }}{{ .name }}{{ range .items }} {{ .id }}{{ end }}{{ end }}`,
+			Args: helper.TestArgs{
+				"yaml": "name: list\nitems:\n  - id: 1\n  - id: 2\n",
+			},
+			Want: "list 1 2",
+		},
+		{
+			Name:     "scalar",
+			Template: `{{ fromYAML "42" }}`,
+			Want:     "42",
+		},
+		{
+			Name:     "empty",
+			Template: `{{ fromYAML "" }}`,
+			Want:     "<no value>",
+		},
+		{
+			Name:     "bad yaml",
+			Template: `{{ fromYAML .yaml }}`,
+			Args: helper.TestArgs{
+				"yaml": "a: [1, 2",
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestFromCSV provides unit test coverage for FromCSV()
+func TestFromCSV(t *testing.T) {
+	got, err := FromCSV("name,size\nwidget,3\n\"gadget, large\",5\n")
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{"name": "widget", "size": "3"},
+		map[string]any{"name": "gadget, large", "size": "5"},
+	}, got)
+
+	tests := []helper.TestSet{
+		{
+			Name:     "rows",
+			Template: `{{ range fromCSV .csv }}{{ .name }}={{ .size }};{{ end }}`,
+			Args: helper.TestArgs{
+				"csv": "name,size\nwidget,3\ngadget,5",
+			},
+			Want: "widget=3;gadget=5;",
+		},
+		{
+			Name:     "header only",
+			Template: `{{ fromCSV "name,size" }}`,
+			Want:     "[]",
+		},
+		{
+			Name:     "empty",
+			Template: `{{ fromCSV "" }}`,
+			Want:     "[]",
+		},
+		{
+			Name:     "wrong number of fields",
+			Template: `{{ fromCSV .csv }}`,
+			Args: helper.TestArgs{
+				"csv": "name,size\nwidget\n",
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "bad header",
+			Template: `{{ fromCSV .csv }}`,
+			Args: helper.TestArgs{
+				"csv": "\"name,size\n",
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestFromINI provides unit test coverage for FromINI()
+func TestFromINI(t *testing.T) {
+	got, err := FromINI(`
+; a comment
+global = yes
+
+[server]
+host = example.com
+port: 8080
+# another comment
+name = "quoted value"
+
+[client.tls]
+verify='false'
+empty =
+
+[server]
+timeout = 30
+`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"global": "yes",
+		"server": map[string]any{
+			"host":    "example.com",
+			"port":    "8080",
+			"name":    "quoted value",
+			"timeout": "30",
+		},
+		"client.tls": map[string]any{
+			"verify": "false",
+			"empty":  "",
+		},
+	}, got)
+
+	tests := []helper.TestSet{
+		{
+			Name:     "lookup",
+			Template: `{{ with fromINI .ini }}{{ .server.host }}:{{ .server.port }}{{ end }}`,
+			Args: helper.TestArgs{
+				"ini": "[server]\nhost=localhost\nport=80\n",
+			},
+			Want: "localhost:80",
+		},
+		{
+			Name:     "unterminated section",
+			Template: `{{ fromINI "[server" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "missing value",
+			Template: `{{ fromINI "[server]\nhost" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -0,0 +1,491 @@
+package encoding
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDocument is a nested value used for the encoder tests
+var testDocument = map[string]any{
+	"name":    "tplr",
+	"version": 1.0,
+	"debug":   false,
+	"tags":    []any{"cli", "templates"},
+	"server": map[string]any{
+		"host": "example.com",
+		"port": 8080,
+		"tls":  map[string]any{"enabled": true},
+	},
+}
+
+// TestToTOML provides unit test coverage for ToTOML()
+func TestToTOML(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "document",
+			Template: `{{ toTOML .D }}`,
+			Args:     helper.TestArgs{"D": testDocument},
+			Want: "debug = false\n" +
+				"name = \"tplr\"\n" +
+				"tags = [\"cli\", \"templates\"]\n" +
+				"version = 1\n" +
+				"\n[server]\n" +
+				"host = \"example.com\"\n" +
+				"port = 8080\n" +
+				"\n[server.tls]\n" +
+				"enabled = true\n",
+		},
+		{
+			Name:     "array of tables",
+			Template: `{{ toTOML .D }}`,
+			Args: helper.TestArgs{"D": map[string]any{
+				"products": []map[string]any{{"name": "Hammer"}, {"name": "Nail", "dims": map[string]int{"l": 3}}},
+			}},
+			Want: "[[products]]\n" +
+				"name = \"Hammer\"\n" +
+				"\n[[products]]\n" +
+				"name = \"Nail\"\n" +
+				"\n[products.dims]\n" +
+				"l = 3\n",
+		},
+		{
+			Name:     "quoting and inline tables",
+			Template: `{{ toTOML .D }}`,
+			Args: helper.TestArgs{"D": map[string]any{
+				"a key": "say \"hi\"\n",
+				"when":  time.Date(2023, 5, 18, 21, 0, 51, 0, time.UTC),
+				"mixed": []any{1, map[string]any{"x": 1.5}},
+				"none":  nil,
+			}},
+			Want: "\"a key\" = \"say \\\"hi\\\"\\n\"\n" +
+				"mixed = [1, { x = 1.5 }]\n" +
+				"when = 2023-05-18T21:00:51Z\n",
+		},
+		{
+			Name:     "not a dictionary",
+			Template: `{{ toTOML .D }}`,
+			Args:     helper.TestArgs{"D": []int{1}},
+			WantErr:  true,
+		},
+		{
+			Name:     "nil in a list",
+			Template: `{{ toTOML .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"l": []any{nil}}},
+			WantErr:  true,
+		},
+		{
+			Name:     "largest integer",
+			Template: `{{ toTOML .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"n": uint64(math.MaxInt64)}},
+			Want:     "n = 9223372036854775807\n",
+		},
+		{
+			Name:     "unsigned integer out of range",
+			Template: `{{ toTOML .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"n": uint64(math.MaxInt64) + 1}},
+			WantErr:  true,
+		},
+		{
+			Name:     "json number out of range",
+			Template: `{{ toTOML .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"n": json.Number("18446744073709551615")}},
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestToTOMLRoundTrip checks that FromTOML reads back what ToTOML writes
+func TestToTOMLRoundTrip(t *testing.T) {
+	want := map[string]any{
+		"title":    "round\ttrip",
+		"count":    int64(3),
+		"ratio":    0.25,
+		"when":     time.Date(2023, 5, 18, 21, 0, 51, 0, time.UTC),
+		"empty":    map[string]any{},
+		"products": []any{map[string]any{"name": "Hammer"}, map[string]any{"name": "Nail"}},
+		"nested":   map[string]any{"deeper": map[string]any{"list": []any{int64(1), "two"}}},
+	}
+
+	s, err := ToTOML(want)
+	require.NoError(t, err)
+	got, err := FromTOML(s)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+// TestToXML provides unit test coverage for ToXML() and ToXMLWith()
+func TestToXML(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "document",
+			Template: `{{ toXML .D }}`,
+			Args:     helper.TestArgs{"D": testDocument},
+			Want: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				"<root>\n" +
+				"  <debug>false</debug>\n" +
+				"  <name>tplr</name>\n" +
+				"  <server>\n" +
+				"    <host>example.com</host>\n" +
+				"    <port>8080</port>\n" +
+				"    <tls>\n" +
+				"      <enabled>true</enabled>\n" +
+				"    </tls>\n" +
+				"  </server>\n" +
+				"  <tags>cli</tags>\n" +
+				"  <tags>templates</tags>\n" +
+				"  <version>1</version>\n" +
+				"</root>\n",
+		},
+		{
+			Name:     "attributes and text",
+			Template: `{{ toXMLWith .O .D }}`,
+			Args: helper.TestArgs{
+				"O": map[string]any{"root": "link", "header": false, "indent": ""},
+				"D": map[string]any{"@href": "/a?b=1&c=2", "#text": "<here>", "empty": nil},
+			},
+			Want: `<link href="/a?b=1&amp;c=2">&lt;here&gt;<empty/></link>`,
+		},
+		{
+			Name:     "list",
+			Template: `{{ toXMLWith .O .D }}`,
+			Args: helper.TestArgs{
+				"O": map[string]any{"root": "values", "item": "v", "attrPrefix": "-", "header": false},
+				"D": []any{1, map[string]any{"-n": "2"}},
+			},
+			Want: "<values>\n" +
+				"  <v>1</v>\n" +
+				"  <v n=\"2\"/>\n" +
+				"</values>\n",
+		},
+		{
+			Name:     "bad element name",
+			Template: `{{ toXML .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"a b": 1}},
+			WantErr:  true,
+		},
+		{
+			Name:     "bad attribute name",
+			Template: `{{ toXML .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"@1": 1}},
+			WantErr:  true,
+		},
+		{
+			Name:     "unknown option",
+			Template: `{{ toXMLWith .O 1 }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"rot": "x"}},
+			WantErr:  true,
+		},
+		{
+			Name:     "bad option type",
+			Template: `{{ toXMLWith .O 1 }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"header": "no"}},
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestToCSV provides unit test coverage for ToCSV()
+func TestToCSV(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "list of dictionaries",
+			Template: `{{ toCSV .D }}`,
+			Args: helper.TestArgs{"D": []map[string]any{
+				{"name": "Hammer", "price": 9.5},
+				{"name": "Nail, steel", "tags": []string{"small"}},
+			}},
+			Want: "name,price,tags\n" +
+				"Hammer,9.5,\n" +
+				"\"Nail, steel\",,\"[\"\"small\"\"]\"\n",
+		},
+		{
+			Name:     "list of lists",
+			Template: `{{ toCSV .D }}`,
+			Args:     helper.TestArgs{"D": [][]any{{"a", 1}, {"b", true}}},
+			Want:     "a,1\nb,true\n",
+		},
+		{
+			Name:     "empty",
+			Template: `{{ toCSV .D }}`,
+			Args:     helper.TestArgs{"D": []any{}},
+			Want:     "",
+		},
+		{
+			Name:     "mixed rows",
+			Template: `{{ toCSV .D }}`,
+			Args:     helper.TestArgs{"D": []any{[]any{"a"}, map[string]any{"b": 1}}},
+			WantErr:  true,
+		},
+		{
+			Name:     "scalar row",
+			Template: `{{ toCSV .D }}`,
+			Args:     helper.TestArgs{"D": []any{"a"}},
+			WantErr:  true,
+		},
+		{
+			Name:     "not a list",
+			Template: `{{ toCSV "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestToINI provides unit test coverage for ToINI()
+func TestToINI(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "document",
+			Template: `{{ toINI .D }}`,
+			Args:     helper.TestArgs{"D": testDocument},
+			Want: "debug = false\n" +
+				"name = tplr\n" +
+				"tags = cli, templates\n" +
+				"version = 1\n" +
+				"\n[server]\n" +
+				"host = example.com\n" +
+				"port = 8080\n" +
+				"\n[server.tls]\n" +
+				"enabled = true\n",
+		},
+		{
+			Name:     "quoting",
+			Template: `{{ toINI .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"a": " padded", "b": `"quoted"`, "c": nil}},
+			Want:     "a = \" padded\"\nb = \"\"quoted\"\"\nc = \n",
+		},
+		{
+			Name:     "multiline value",
+			Template: `{{ toINI .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"a": "x\ny"}},
+			WantErr:  true,
+		},
+		{
+			Name:     "nested list",
+			Template: `{{ toINI .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"a": []any{[]any{1}}}},
+			WantErr:  true,
+		},
+		{
+			Name:     "bad key",
+			Template: `{{ toINI .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"a=b": 1}},
+			WantErr:  true,
+		},
+		{
+			Name:     "not a dictionary",
+			Template: `{{ toINI "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestToINIRoundTrip checks that FromINI reads back what ToINI writes
+func TestToINIRoundTrip(t *testing.T) {
+	want := map[string]any{
+		"name":  " spaced ",
+		"quote": `"x"`,
+		"db":    map[string]any{"host": "localhost", "port": "5432"},
+	}
+
+	s, err := ToINI(want)
+	require.NoError(t, err)
+	got, err := FromINI(s)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+// TestToHCL provides unit test coverage for ToHCL()
+func TestToHCL(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "document",
+			Template: `{{ toHCL .D }}`,
+			Args:     helper.TestArgs{"D": testDocument},
+			Want: "debug = false\n" +
+				"name = \"tplr\"\n" +
+				"tags = [\"cli\", \"templates\"]\n" +
+				"version = 1\n" +
+				"\nserver {\n" +
+				"  host = \"example.com\"\n" +
+				"  port = 8080\n" +
+				"\n  tls {\n" +
+				"    enabled = true\n" +
+				"  }\n" +
+				"}\n",
+		},
+		{
+			Name:     "repeated blocks and objects",
+			Template: `{{ toHCL .D }}`,
+			Args: helper.TestArgs{"D": map[string]any{
+				"ingress": []any{map[string]any{"port": 80}, map[string]any{"port": 443}},
+				"tags":    map[string]any{"Name": "web ${env}", "cost-centre": nil, "a b": "%{x}"},
+			}},
+			Want: "tags = {\n" +
+				"  \"Name\" = \"web $${env}\"\n" +
+				"  \"a b\" = \"%%{x}\"\n" +
+				"  \"cost-centre\" = null\n" +
+				"}\n" +
+				"\ningress {\n" +
+				"  port = 80\n" +
+				"}\n" +
+				"\ningress {\n" +
+				"  port = 443\n" +
+				"}\n",
+		},
+		{
+			Name:     "bad attribute name",
+			Template: `{{ toHCL .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"1st": 1}},
+			WantErr:  true,
+		},
+		{
+			Name:     "infinity",
+			Template: `{{ toHCL .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"n": math.Inf(1)}},
+			WantErr:  true,
+		},
+		{
+			Name:     "not a number",
+			Template: `{{ toHCL .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"n": float32(math.NaN())}},
+			WantErr:  true,
+		},
+		{
+			Name:     "not a dictionary",
+			Template: `{{ toHCL "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestToProperties provides unit test coverage for ToProperties()
+func TestToProperties(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "document",
+			Template: `{{ toProperties .D }}`,
+			Args:     helper.TestArgs{"D": testDocument},
+			Want: "debug=false\n" +
+				"name=tplr\n" +
+				"server.host=example.com\n" +
+				"server.port=8080\n" +
+				"server.tls.enabled=true\n" +
+				"tags.0=cli\n" +
+				"tags.1=templates\n" +
+				"version=1\n",
+		},
+		{
+			Name:     "escaping",
+			Template: `{{ toProperties .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"a key=": " café\n😀", "b": `C:\dir`}},
+			Want:     "a\\ key\\==\\ caf\\u00E9\\n\\uD83D\\uDE00\nb=C:\\\\dir\n",
+		},
+		{
+			Name:     "not a dictionary",
+			Template: `{{ toProperties "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestToEnv provides unit test coverage for ToEnv()
+func TestToEnv(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "document",
+			Template: `{{ toEnv .D }}`,
+			Args:     helper.TestArgs{"D": testDocument},
+			Want: "DEBUG=false\n" +
+				"NAME=tplr\n" +
+				"SERVER_HOST=example.com\n" +
+				"SERVER_PORT=8080\n" +
+				"SERVER_TLS_ENABLED=true\n" +
+				"TAGS_0=cli\n" +
+				"TAGS_1=templates\n" +
+				"VERSION=1\n",
+		},
+		{
+			Name:     "escaping",
+			Template: `{{ toEnv .D }}`,
+			Args: helper.TestArgs{"D": map[string]any{
+				"db-url":  "postgres://u:p@host/db",
+				"1st":     `say "$HOME" and ` + "`ls`\n",
+				"empty":   "",
+				"spaced":  "a b",
+				"escaped": `C:\dir`,
+			}},
+			Want: "DB_URL=postgres://u:p@host/db\n" +
+				"EMPTY=\n" +
+				"ESCAPED=\"C:\\\\dir\"\n" +
+				"SPACED=\"a b\"\n" +
+				"_1ST=\"say \\\"\\$HOME\\\" and \\`ls\\`\\n\"\n",
+		},
+		{
+			Name:     "duplicate names",
+			Template: `{{ toEnv .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"a-b": 1, "a_b": 2}},
+			WantErr:  true,
+		},
+		{
+			Name:     "not a dictionary",
+			Template: `{{ toEnv "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestToPrettyJSON provides unit test coverage for ToPrettyJSON()
+func TestToPrettyJSON(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "document",
+			Template: `{{ toPrettyJSON .D }}`,
+			Args:     helper.TestArgs{"D": map[string]any{"b": []int{1, 2}, "a": "<&>"}},
+			Want:     "{\n  \"a\": \"<&>\",\n  \"b\": [\n    1,\n    2\n  ]\n}",
+		},
+		{
+			Name:     "unencodable",
+			Template: `{{ toPrettyJSON .D }}`,
+			Args:     helper.TestArgs{"D": func() {}},
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -8,10 +8,41 @@ import (
 // Functions for encoding and decoding various formats
 func Functions() template.FuncMap {
 	return template.FuncMap{
-		"formatJSON": FormatJSON,
-		"fromBase64": FromBase64,
-		"toBase64":   ToBase64,
-		"toJSON":     ToJSON,
-		"toYAML":     ToYAML,
+		"formatJSON":       FormatJSON,
+		"fromBase64":       FromBase64,
+		"toBase64":         ToBase64,
+		"fromBase64Raw":    FromBase64Raw,
+		"toBase64Raw":      ToBase64Raw,
+		"fromBase64URL":    FromBase64URL,
+		"toBase64URL":      ToBase64URL,
+		"fromBase64URLRaw": FromBase64URLRaw,
+		"toBase64URLRaw":   ToBase64URLRaw,
+		"fromBase32":       FromBase32,
+		"toBase32":         ToBase32,
+		"fromBase32Raw":    FromBase32Raw,
+		"toBase32Raw":      ToBase32Raw,
+		"fromHex":          FromHex,
+		"toHex":            ToHex,
+		"fromAscii85":      FromAscii85,
+		"toAscii85":        ToAscii85,
+		"gzip":             Gzip,
+		"gunzip":           Gunzip,
+		"toJSON":           ToJSON,
+		"toYAML":           ToYAML,
+		"toYAMLWith":       ToYAMLWith,
+		"fromJSON":         FromJSON,
+		"fromYAML":         FromYAML,
+		"fromTOML":         FromTOML,
+		"fromCSV":          FromCSV,
+		"fromINI":          FromINI,
+		"toTOML":           ToTOML,
+		"toXML":            ToXML,
+		"toXMLWith":        ToXMLWith,
+		"toCSV":            ToCSV,
+		"toINI":            ToINI,
+		"toHCL":            ToHCL,
+		"toProperties":     ToProperties,
+		"toEnv":            ToEnv,
+		"toPrettyJSON":     ToPrettyJSON,
 	}
 }
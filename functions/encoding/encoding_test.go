@@ -10,7 +10,7 @@ import (
 // // TestEncodingFunctions provides unit test coverage for EncodingFunctions
 func TestEncodingFunctions(t *testing.T) {
 	fn := Functions()
-	assert.Len(t, fn, 5, "weakly ensuring functions haven't been added/removed without updating tests")
+	assert.Len(t, fn, 36, "weakly ensuring functions haven't been added/removed without updating tests")
 }
 
 // TestToJSON provides unit test coverage for ToJSON()
@@ -83,6 +83,126 @@ func TestToYAML(t *testing.T) {
 	}
 }
 
+// TestToYAMLWith provides unit test coverage for ToYAMLWith()
+func TestToYAMLWith(t *testing.T) {
+	doc := map[string]any{
+		"b": []any{1, "two"},
+		"a": map[string]any{"x": "l1\nl2\n", "y": "true"},
+	}
+
+	tests := []helper.TestSet{
+		{
+			Name:     "defaults",
+			Template: `{{ toYAMLWith .O .D }}`,
+			Args:     helper.TestArgs{"O": nil, "D": doc},
+			Want:     "a:\n  x: |\n    l1\n    l2\n  y: \"true\"\nb:\n  - 1\n  - two\n",
+		},
+		{
+			Name:     "indent",
+			Template: `{{ toYAMLWith .O .D }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"indent": 4}, "D": map[string]any{"a": map[string]any{"b": []int{1}}}},
+			Want:     "a:\n    b:\n        - 1\n",
+		},
+		{
+			Name:     "folded",
+			Template: `{{ toYAMLWith .O .D }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"multiline": "folded"}, "D": map[string]any{"x": "l1\nl2\n"}},
+			Want:     "x: >\n  l1\n\n  l2\n\n",
+		},
+		{
+			Name:     "quoted without trailing newline",
+			Template: `{{ toYAMLWith .O .D }}`,
+			Args: helper.TestArgs{
+				"O": map[string]any{"multiline": "quoted", "trailingNewline": false},
+				"D": map[string]any{"x": "l1\nl2"},
+			},
+			Want: `x: "l1\nl2"`,
+		},
+		{
+			Name:     "flow scalars",
+			Template: `{{ toYAMLWith .O .D }}`,
+			Args: helper.TestArgs{
+				"O": map[string]any{"flow": "scalars"},
+				"D": map[string]any{"a": map[string]any{"b": []int{1, 2}, "c": 3}},
+			},
+			Want: "a:\n  b: [1, 2]\n  c: 3\n",
+		},
+		{
+			Name:     "flow always",
+			Template: `{{ toYAMLWith .O .D }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"flow": "always"}, "D": doc},
+			Want:     "{a: {x: \"l1\\nl2\\n\", y: \"true\"}, b: [1, two]}\n",
+		},
+		{
+			Name:     "documents",
+			Template: `{{ toYAMLWith .O .D }}`,
+			Args: helper.TestArgs{
+				"O": map[string]any{"documents": true},
+				"D": []any{map[string]any{"kind": "Service"}, map[string]any{"kind": "Deployment"}},
+			},
+			Want: "kind: Service\n---\nkind: Deployment\n",
+		},
+		{
+			Name:     "keep order of json",
+			Template: `{{ toYAMLWith .O .D }}`,
+			Args: helper.TestArgs{
+				"O": map[string]any{"keepOrder": true},
+				"D": `{"kind": "Pod", "apiVersion": "v1", "spec": {"z": 1, "a": ["x", "true"]}}`,
+			},
+			Want: "kind: Pod\napiVersion: v1\nspec:\n  z: 1\n  a:\n    - x\n    - \"true\"\n",
+		},
+		{
+			Name:     "keep order of yaml",
+			Template: `{{ toYAMLWith .O .D }}`,
+			Args: helper.TestArgs{
+				"O": map[string]any{"keepOrder": true},
+				"D": "# config\nz: 1 # last\na: 'q'\n",
+			},
+			Want: "# config\nz: 1 # last\na: q\n",
+		},
+		{
+			Name:     "keep order of nothing",
+			Template: `{{ toYAMLWith .O "" }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"keepOrder": true}},
+			Want:     "null\n",
+		},
+		{
+			Name:     "keep order of invalid yaml",
+			Template: `{{ toYAMLWith .O "a: [" }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"keepOrder": true}},
+			WantErr:  true,
+		},
+		{
+			Name:     "bad indent",
+			Template: `{{ toYAMLWith .O 1 }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"indent": 1}},
+			WantErr:  true,
+		},
+		{
+			Name:     "bad multiline",
+			Template: `{{ toYAMLWith .O 1 }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"multiline": "plain"}},
+			WantErr:  true,
+		},
+		{
+			Name:     "bad flow",
+			Template: `{{ toYAMLWith .O 1 }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"flow": true}},
+			WantErr:  true,
+		},
+		{
+			Name:     "unknown option",
+			Template: `{{ toYAMLWith .O 1 }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"width": 80}},
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
 // TestToBase64 provides unit test coverage for ToBase64()
 func TestToBase64(t *testing.T) {
 	tests := []helper.TestSet{
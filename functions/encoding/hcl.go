@@ -0,0 +1,147 @@
+package encoding
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// hclIdentifier matches names that can be used for attributes and blocks
+var hclIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// ToHCL returns the given dictionary in HCL (as used by terraform and other tools), with keys in sorted order.
+// Dictionaries become blocks and lists of dictionaries become repeated blocks,
+// unless they have keys that can't be used as names, in which case they're written as objects
+func ToHCL(val any) (string, error) {
+	m, ok := plain(val).(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("an hcl body must be a dictionary, not %T", val)
+	}
+	var sb strings.Builder
+	if err := writeHCLBody(&sb, m, 0); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// writeHCLBody writes attributes, followed by blocks
+func writeHCLBody(sb *strings.Builder, m map[string]any, depth int) error {
+	indent := strings.Repeat("  ", depth)
+	keys := sortedKeys(m)
+
+	var blocks []string
+	for _, k := range keys {
+		if isHCLBlock(m[k]) {
+			blocks = append(blocks, k)
+			continue
+		}
+		if !hclIdentifier.MatchString(k) {
+			return fmt.Errorf("%q can't be used as an hcl attribute name", k)
+		}
+		v, err := hclValue(m[k], depth)
+		if err != nil {
+			return fmt.Errorf("attribute %q: %w", k, err)
+		}
+		sb.WriteString(indent + k + " = " + v + "\n")
+	}
+
+	for _, k := range blocks {
+		var bodies []any
+		if l, ok := m[k].([]any); ok {
+			bodies = l
+		} else {
+			bodies = []any{m[k]}
+		}
+		for _, b := range bodies {
+			if sb.Len() > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(indent + k + " {\n")
+			if err := writeHCLBody(sb, b.(map[string]any), depth+1); err != nil {
+				return err
+			}
+			sb.WriteString(indent + "}\n")
+		}
+	}
+	return nil
+}
+
+// isHCLBlock returns true for dictionaries (or lists of them) that can be written as blocks
+func isHCLBlock(v any) bool {
+	switch t := v.(type) {
+	case map[string]any:
+		for k := range t {
+			if !hclIdentifier.MatchString(k) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		if !isTableList(t) {
+			return false
+		}
+		for _, e := range t {
+			if !isHCLBlock(e) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// hclValue formats a value as an expression
+func hclValue(v any, depth int) (string, error) {
+	if n, ok := formatNumber(v); ok {
+		if nonFinite(v) {
+			return "", fmt.Errorf("hcl has no equivalent of %s", n)
+		}
+		return n, nil
+	}
+
+	switch t := v.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return hclString(t), nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	case time.Time:
+		return hclString(t.Format(time.RFC3339Nano)), nil
+	case []any:
+		items := make([]string, len(t))
+		for i, e := range t {
+			s, err := hclValue(e, depth)
+			if err != nil {
+				return "", err
+			}
+			items[i] = s
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]any:
+		if len(t) == 0 {
+			return "{}", nil
+		}
+		indent := strings.Repeat("  ", depth)
+		var sb strings.Builder
+		sb.WriteString("{\n")
+		for _, k := range sortedKeys(t) {
+			s, err := hclValue(t[k], depth+1)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(indent + "  " + hclString(k) + " = " + s + "\n")
+		}
+		sb.WriteString(indent + "}")
+		return sb.String(), nil
+	}
+	return hclString(fmt.Sprint(v)), nil
+}
+
+// hclString quotes and escapes a string, including the template sequences "${" and "%{"
+func hclString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "${", "$${", "%{", "%%{")
+	return `"` + r.Replace(s) + `"`
+}
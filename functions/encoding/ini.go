@@ -0,0 +1,142 @@
+package encoding
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// FromINI decodes the given ini string. Each section becomes a map of its keys to their (string) values,
+// keys before the first section are at the top level
+func FromINI(s string) (map[string]any, error) {
+	res := map[string]any{}
+	section := res
+
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for line := 1; scanner.Scan(); line++ {
+		l := strings.TrimSpace(scanner.Text())
+		if l == "" || l[0] == ';' || l[0] == '#' {
+			continue
+		}
+
+		if l[0] == '[' {
+			if l[len(l)-1] != ']' {
+				return nil, fmt.Errorf("failed to parse ini on line %d: unterminated section name", line)
+			}
+			name := strings.TrimSpace(l[1 : len(l)-1])
+			s, ok := res[name].(map[string]any)
+			if !ok {
+				s = map[string]any{}
+				res[name] = s
+			}
+			section = s
+			continue
+		}
+
+		i := strings.IndexAny(l, "=:")
+		if i < 1 {
+			return nil, fmt.Errorf("failed to parse ini on line %d: expected key = value", line)
+		}
+		key := strings.TrimSpace(l[:i])
+		section[key] = unquoteINI(strings.TrimSpace(l[i+1:]))
+	}
+
+	return res, scanner.Err()
+}
+
+// unquoteINI removes matching quotes surrounding a value
+func unquoteINI(v string) string {
+	if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+		return v[1 : len(v)-1]
+	}
+	return v
+}
+
+// ToINI returns the given dictionary as an ini file. Dictionaries become sections (nested ones named
+// with dotted paths), lists are written as comma separated values, and keys are in sorted order
+func ToINI(val any) (string, error) {
+	m, ok := plain(val).(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("an ini file must be a dictionary, not %T", val)
+	}
+
+	var sb strings.Builder
+	if err := writeINISection(&sb, "", m); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// writeINISection writes the values of a section, followed by its sub-sections
+func writeINISection(sb *strings.Builder, name string, m map[string]any) error {
+	keys := sortedKeys(m)
+	if name != "" && (hasINIValues(m) || len(m) == 0) {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("[" + name + "]\n")
+	}
+
+	for _, k := range keys {
+		if isTable(m[k]) {
+			continue
+		}
+		if strings.ContainsAny(k, "=:[]\n") || strings.TrimSpace(k) != k || k == "" {
+			return fmt.Errorf("%q can't be used as an ini key", k)
+		}
+		v, err := iniValue(m[k])
+		if err != nil {
+			return fmt.Errorf("key %q: %w", k, err)
+		}
+		sb.WriteString(k + " = " + v + "\n")
+	}
+
+	for _, k := range keys {
+		if s, ok := m[k].(map[string]any); ok {
+			sub := k
+			if name != "" {
+				sub = name + "." + k
+			}
+			if err := writeINISection(sb, sub, s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasINIValues returns true if the section contains anything other than sub-sections
+func hasINIValues(m map[string]any) bool {
+	for _, v := range m {
+		if !isTable(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// iniValue formats a value, quoting it if it would otherwise lose leading or trailing space
+func iniValue(v any) (string, error) {
+	var s string
+	if l, ok := v.([]any); ok {
+		items := make([]string, len(l))
+		for i, e := range l {
+			switch e.(type) {
+			case map[string]any, []any:
+				return "", fmt.Errorf("ini values can't contain nested lists or dictionaries")
+			}
+			items[i] = scalarString(e)
+		}
+		s = strings.Join(items, ", ")
+	} else {
+		s = scalarString(v)
+	}
+
+	if strings.ContainsAny(s, "\r\n") {
+		return "", fmt.Errorf("ini values can't span multiple lines")
+	}
+	if strings.TrimSpace(s) != s || unquoteINI(s) != s {
+		s = `"` + s + `"`
+	}
+	return s, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // ToJSON returns the given value as a json string
@@ -21,3 +22,15 @@ func FormatJSON(indent string, j string) (string, error) {
 	}
 	return buf.String(), nil
 }
+
+// ToPrettyJSON returns the given value as an indented json string, with dictionary keys in sorted order
+func ToPrettyJSON(val any) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(val); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
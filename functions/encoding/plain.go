@@ -0,0 +1,196 @@
+package encoding
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// plain converts a value to its basic structure of map[string]any, []any and scalars,
+// so that the encoders only have to deal with those types.
+// Structs are converted to maps of their exported fields, and pointers are followed
+func plain(v any) any {
+	if v == nil {
+		return nil
+	}
+	switch v.(type) {
+	case map[string]any, []any, string, bool, time.Time:
+		return plainKnown(v)
+	}
+	return plainValue(reflect.ValueOf(v))
+}
+
+// plainKnown avoids reflection for the common types
+func plainKnown(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(t))
+		for k, e := range t {
+			m[k] = plain(e)
+		}
+		return m
+	case []any:
+		l := make([]any, len(t))
+		for i, e := range t {
+			l[i] = plain(e)
+		}
+		return l
+	}
+	return v
+}
+
+func plainValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return plainValue(v.Elem())
+	case reflect.Map:
+		m := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = plainValue(iter.Value())
+		}
+		return m
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes())
+		}
+		fallthrough
+	case reflect.Array:
+		l := make([]any, v.Len())
+		for i := range l {
+			l[i] = plainValue(v.Index(i))
+		}
+		return l
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface()
+		}
+		m := map[string]any{}
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Type().Field(i); f.IsExported() {
+				m[f.Name] = plainValue(v.Field(i))
+			}
+		}
+		return m
+	}
+
+	if !v.CanInterface() {
+		return nil
+	}
+	if n, ok := v.Interface().(json.Number); ok {
+		return n
+	}
+
+	// named types are converted to their underlying type
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	return v.Interface()
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isTable returns true for values that are encoded as a section/table/block rather than a simple value
+func isTable(v any) bool {
+	_, ok := v.(map[string]any)
+	return ok
+}
+
+// isTableList returns true for non-empty lists made up entirely of tables
+func isTableList(v any) bool {
+	l, ok := v.([]any)
+	if !ok || len(l) == 0 {
+		return false
+	}
+	for _, e := range l {
+		if !isTable(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatNumber writes numbers without exponents where possible, and whole floats (as used by json) as integers
+func formatNumber(v any) (string, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		return n.String(), true
+	case float64:
+		return formatFloat(n), true
+	case float32:
+		return formatFloat(float64(n)), true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(n), true
+	}
+	return "", false
+}
+
+// nonFinite returns true for the floating point values that aren't numbers: infinity and NaN
+func nonFinite(v any) bool {
+	var f float64
+	switch n := v.(type) {
+	case float64:
+		f = n
+	case float32:
+		f = float64(n)
+	default:
+		return false
+	}
+	return math.IsInf(f, 0) || math.IsNaN(f)
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	case math.IsNaN(f):
+		return "nan"
+	case f == math.Trunc(f) && math.Abs(f) < 1e15:
+		return strconv.FormatFloat(f, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// scalarString converts a simple value to a string
+func scalarString(v any) string {
+	if v == nil {
+		return ""
+	}
+	if n, ok := formatNumber(v); ok {
+		return n
+	}
+	if t, ok := v.(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,141 @@
+package encoding
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// envSafe matches values that don't need quoting in an env file
+var envSafe = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+
+// flatten converts nested dictionaries and lists to a single level, joining the keys (and list indexes) with sep
+func flatten(prefix string, sep string, v any, out map[string]any) {
+	join := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + sep + k
+	}
+
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			flatten(join(k), sep, e, out)
+		}
+	case []any:
+		for i, e := range t {
+			flatten(join(strconv.Itoa(i)), sep, e, out)
+		}
+	default:
+		out[prefix] = v
+	}
+}
+
+// ToProperties returns the given dictionary as a java properties file, with nested keys joined by '.'
+// and lists indexed from 0, eg "server.hosts.0=example.com"
+func ToProperties(val any) (string, error) {
+	m, ok := plain(val).(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("properties must be a dictionary, not %T", val)
+	}
+
+	flat := map[string]any{}
+	flatten("", ".", m, flat)
+
+	var sb strings.Builder
+	for _, k := range sortedKeys(flat) {
+		sb.WriteString(propertiesEscape(k, true) + "=" + propertiesEscape(scalarString(flat[k]), false) + "\n")
+	}
+	return sb.String(), nil
+}
+
+// propertiesEscape escapes a key or value. Characters outside of ascii are written as unicode escapes
+func propertiesEscape(s string, key bool) string {
+	var sb strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r == '\f':
+			sb.WriteString(`\f`)
+		case r == ' ' && (key || i == 0):
+			sb.WriteString(`\ `)
+		case key && strings.ContainsRune("=:#!", r):
+			sb.WriteString(`\` + string(r))
+		case r < 0x20 || r > 0x7e:
+			for _, u := range utf16Units(r) {
+				fmt.Fprintf(&sb, `\u%04X`, u)
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// utf16Units returns the utf-16 encoding of a rune, which is a surrogate pair for those outside the basic plane
+func utf16Units(r rune) []rune {
+	if r < 0x10000 {
+		return []rune{r}
+	}
+	r -= 0x10000
+	return []rune{0xD800 + (r>>10)&0x3FF, 0xDC00 + r&0x3FF}
+}
+
+// ToEnv returns the given dictionary as KEY=value lines for use as a .env file or shell variables.
+// Nested keys are joined by '_', and upper-cased with anything other than letters, digits and '_'
+// replaced by '_'. Values are double-quoted (and escaped) if they contain anything unsafe
+func ToEnv(val any) (string, error) {
+	m, ok := plain(val).(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("env variables must be a dictionary, not %T", val)
+	}
+
+	flat := map[string]any{}
+	flatten("", "_", m, flat)
+
+	vars := map[string]any{}
+	for k, v := range flat {
+		name := envName(k)
+		if _, dup := vars[name]; dup {
+			return "", fmt.Errorf("more than one key is converted to the env variable %s", name)
+		}
+		vars[name] = v
+	}
+
+	var sb strings.Builder
+	for _, k := range sortedKeys(vars) {
+		sb.WriteString(k + "=" + envValue(scalarString(vars[k])) + "\n")
+	}
+	return sb.String(), nil
+}
+
+func envName(k string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, k)
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+func envValue(s string) string {
+	if envSafe.MatchString(s) {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`", "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
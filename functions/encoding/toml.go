@@ -0,0 +1,811 @@
+package encoding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FromTOML decodes the given TOML document.
+// Tables become map[string]any, arrays []any, integers int64, floats float64
+// and date-times with an offset time.Time. Local dates and times are left as strings
+func FromTOML(s string) (map[string]any, error) {
+	p := &tomlParser{
+		src:     s,
+		line:    1,
+		root:    map[string]any{},
+		defined: map[uintptr]bool{},
+		inline:  map[uintptr]bool{},
+		arrays:  map[tomlSlot]bool{},
+	}
+	if err := p.parse(); err != nil {
+		return nil, fmt.Errorf("failed to parse toml on line %d: %w", p.line, err)
+	}
+	return p.root, nil
+}
+
+// tomlParser holds the state of a TOML document being parsed
+type tomlParser struct {
+	src     string
+	at      int
+	line    int
+	root    map[string]any
+	current map[string]any
+	defined map[uintptr]bool  // tables defined by a header
+	inline  map[uintptr]bool  // inline tables, which can't be extended once they're closed
+	arrays  map[tomlSlot]bool // arrays of tables, as opposed to static arrays (which can't be extended)
+}
+
+// tomlSlot identifies a key within a table
+type tomlSlot struct {
+	table uintptr
+	key   string
+}
+
+func (p *tomlParser) eof() bool {
+	return p.at >= len(p.src)
+}
+
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.at]
+}
+
+func (p *tomlParser) hasPrefix(s string) bool {
+	return strings.HasPrefix(p.src[p.at:], s)
+}
+
+// skipSpace skips spaces and tabs
+func (p *tomlParser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.at++
+	}
+}
+
+// skipComment skips a comment up to (but not including) the end of the line
+func (p *tomlParser) skipComment() {
+	if p.peek() != '#' {
+		return
+	}
+	for !p.eof() && p.peek() != '\n' {
+		p.at++
+	}
+}
+
+// skipBlank skips whitespace, comments and newlines
+func (p *tomlParser) skipBlank() {
+	for {
+		p.skipSpace()
+		p.skipComment()
+		switch {
+		case p.hasPrefix("\n"):
+			p.at++
+			p.line++
+		case p.hasPrefix("\r\n"):
+			p.at += 2
+			p.line++
+		default:
+			return
+		}
+	}
+}
+
+// endOfLine consumes the rest of a line, which may only contain a comment
+func (p *tomlParser) endOfLine() error {
+	p.skipSpace()
+	p.skipComment()
+	switch {
+	case p.eof():
+		return nil
+	case p.hasPrefix("\n"):
+		p.at++
+	case p.hasPrefix("\r\n"):
+		p.at += 2
+	default:
+		return fmt.Errorf("unexpected %q after value", p.peek())
+	}
+	p.line++
+	return nil
+}
+
+func (p *tomlParser) expect(s string) error {
+	if !p.hasPrefix(s) {
+		if p.eof() {
+			return fmt.Errorf("expected %q at end of document", s)
+		}
+		return fmt.Errorf("expected %q, found %q", s, p.peek())
+	}
+	p.at += len(s)
+	return nil
+}
+
+func (p *tomlParser) parse() error {
+	p.current = p.root
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil
+		}
+
+		var err error
+		switch {
+		case p.hasPrefix("[["):
+			err = p.parseArrayTableHeader()
+		case p.hasPrefix("["):
+			err = p.parseTableHeader()
+		default:
+			err = p.parseKeyValue(p.current)
+		}
+		if err != nil {
+			return err
+		}
+		if err = p.endOfLine(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *tomlParser) parseTableHeader() error {
+	p.at++
+	path, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	if err = p.expect("]"); err != nil {
+		return err
+	}
+
+	t, err := p.table(p.root, path)
+	if err != nil {
+		return err
+	}
+	id := reflect.ValueOf(t).Pointer()
+	if p.defined[id] {
+		return fmt.Errorf("table [%s] is defined more than once", strings.Join(path, "."))
+	}
+	p.defined[id] = true
+	p.current = t
+	return nil
+}
+
+func (p *tomlParser) parseArrayTableHeader() error {
+	p.at += 2
+	path, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	if err = p.expect("]]"); err != nil {
+		return err
+	}
+
+	parent, err := p.table(p.root, path[:len(path)-1])
+	if err != nil {
+		return err
+	}
+
+	key := path[len(path)-1]
+	slot := tomlSlot{table: reflect.ValueOf(parent).Pointer(), key: key}
+	t := map[string]any{}
+	switch v := parent[key].(type) {
+	case nil:
+		parent[key] = []any{t}
+		p.arrays[slot] = true
+	case []any:
+		if !p.arrays[slot] {
+			return fmt.Errorf("static array %q can't be extended", strings.Join(path, "."))
+		}
+		parent[key] = append(v, t)
+	default:
+		return fmt.Errorf("key %q is not an array of tables", strings.Join(path, "."))
+	}
+	p.current = t
+	return nil
+}
+
+// table finds (or creates) the table at the given path, within t. Arrays of tables refer to their last element
+func (p *tomlParser) table(t map[string]any, path []string) (map[string]any, error) {
+	for i, k := range path {
+		switch v := t[k].(type) {
+		case nil:
+			n := map[string]any{}
+			t[k] = n
+			t = n
+		case map[string]any:
+			t = v
+		case []any:
+			if len(v) == 0 {
+				return nil, fmt.Errorf("key %q is not a table", strings.Join(path[:i+1], "."))
+			}
+			last, ok := v[len(v)-1].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("key %q is not a table", strings.Join(path[:i+1], "."))
+			}
+			t = last
+		default:
+			return nil, fmt.Errorf("key %q is not a table", strings.Join(path[:i+1], "."))
+		}
+		if p.inline[reflect.ValueOf(t).Pointer()] {
+			return nil, fmt.Errorf("inline table %q can't be extended", strings.Join(path[:i+1], "."))
+		}
+	}
+	return t, nil
+}
+
+// closeInline marks the (just parsed) inline table, and any tables within it, as closed to further keys
+func (p *tomlParser) closeInline(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		p.inline[reflect.ValueOf(v).Pointer()] = true
+		for _, e := range v {
+			p.closeInline(e)
+		}
+	case []any:
+		for _, e := range v {
+			p.closeInline(e)
+		}
+	}
+}
+
+// parseKey parses a bare, quoted or dotted key into its parts
+func (p *tomlParser) parseKey() ([]string, error) {
+	var path []string
+	for {
+		p.skipSpace()
+		var k string
+		var err error
+		switch p.peek() {
+		case '"':
+			k, err = p.parseBasicString()
+		case '\'':
+			k, err = p.parseLiteralString()
+		default:
+			start := p.at
+			for !p.eof() && isBareKeyChar(p.peek()) {
+				p.at++
+			}
+			if p.at == start {
+				if p.eof() {
+					return nil, fmt.Errorf("expected a key at end of document")
+				}
+				return nil, fmt.Errorf("expected a key, found %q", p.peek())
+			}
+			k = p.src[start:p.at]
+		}
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, k)
+
+		p.skipSpace()
+		if p.peek() != '.' {
+			return path, nil
+		}
+		p.at++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (p *tomlParser) parseKeyValue(t map[string]any) error {
+	path, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	if err = p.expect("="); err != nil {
+		return err
+	}
+	p.skipSpace()
+	v, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	t, err = p.table(t, path[:len(path)-1])
+	if err != nil {
+		return err
+	}
+	key := path[len(path)-1]
+	if _, ok := t[key]; ok {
+		return fmt.Errorf("duplicate key %q", strings.Join(path, "."))
+	}
+	t[key] = v
+	return nil
+}
+
+func (p *tomlParser) parseValue() (any, error) {
+	switch {
+	case p.hasPrefix(`"""`):
+		return p.parseMultilineString(`"""`)
+	case p.hasPrefix(`'''`):
+		return p.parseMultilineString(`'''`)
+	case p.hasPrefix(`"`):
+		return p.parseBasicString()
+	case p.hasPrefix(`'`):
+		return p.parseLiteralString()
+	case p.hasPrefix("["):
+		return p.parseArray()
+	case p.hasPrefix("{"):
+		return p.parseInlineTable()
+	case p.eof():
+		return nil, fmt.Errorf("expected a value at end of document")
+	}
+	return p.parseScalar()
+}
+
+func (p *tomlParser) parseArray() ([]any, error) {
+	p.at++
+	a := []any{}
+	for {
+		p.skipBlank()
+		if p.peek() == ']' {
+			p.at++
+			return a, nil
+		}
+
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, v)
+
+		p.skipBlank()
+		switch p.peek() {
+		case ',':
+			p.at++
+		case ']':
+		default:
+			return nil, fmt.Errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+func (p *tomlParser) parseInlineTable() (map[string]any, error) {
+	p.at++
+	t := map[string]any{}
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.at++
+		p.closeInline(t)
+		return t, nil
+	}
+
+	for {
+		if err := p.parseKeyValue(t); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.at++
+		case '}':
+			p.at++
+			p.closeInline(t)
+			return t, nil
+		default:
+			return nil, fmt.Errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.at++
+	end := strings.IndexAny(p.src[p.at:], "'\n")
+	if end < 0 || p.src[p.at+end] != '\'' {
+		return "", fmt.Errorf("unterminated string")
+	}
+	s := p.src[p.at : p.at+end]
+	p.at += end + 1
+	return s, nil
+}
+
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.at++
+	var sb strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", fmt.Errorf("unterminated string")
+		}
+		c := p.peek()
+		switch c {
+		case '"':
+			p.at++
+			return sb.String(), nil
+		case '\\':
+			if err := p.parseEscape(&sb); err != nil {
+				return "", err
+			}
+		default:
+			sb.WriteByte(c)
+			p.at++
+		}
+	}
+}
+
+// parseMultilineString parses a string delimited by three double (or single) quotes
+func (p *tomlParser) parseMultilineString(delim string) (string, error) {
+	p.at += len(delim)
+	// a newline straight after the opening delimiter is trimmed
+	if p.hasPrefix("\n") {
+		p.at++
+		p.line++
+	} else if p.hasPrefix("\r\n") {
+		p.at += 2
+		p.line++
+	}
+
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return "", fmt.Errorf("unterminated string")
+		}
+		if p.hasPrefix(delim) {
+			// up to two quotes can be placed directly before the closing delimiter
+			n := 0
+			for p.at+n < len(p.src) && p.src[p.at+n] == delim[0] && n < 5 {
+				n++
+			}
+			sb.WriteString(p.src[p.at : p.at+n-3])
+			p.at += n
+			return sb.String(), nil
+		}
+
+		c := p.peek()
+		switch {
+		case c == '\\' && delim == `"""`:
+			if p.lineEndingBackslash() {
+				continue
+			}
+			if err := p.parseEscape(&sb); err != nil {
+				return "", err
+			}
+		default:
+			if c == '\n' {
+				p.line++
+			}
+			sb.WriteByte(c)
+			p.at++
+		}
+	}
+}
+
+// lineEndingBackslash trims a backslash at the end of a line, and all the whitespace following it
+func (p *tomlParser) lineEndingBackslash() bool {
+	i := p.at + 1
+	for i < len(p.src) && (p.src[i] == ' ' || p.src[i] == '\t' || p.src[i] == '\r') {
+		i++
+	}
+	if i >= len(p.src) || p.src[i] != '\n' {
+		return false
+	}
+	for i < len(p.src) && strings.IndexByte(" \t\r\n", p.src[i]) >= 0 {
+		if p.src[i] == '\n' {
+			p.line++
+		}
+		i++
+	}
+	p.at = i
+	return true
+}
+
+// tomlEscapes are the single character escape sequences, and the characters they represent
+var tomlEscapes = map[byte]byte{'b': '\b', 't': '\t', 'n': '\n', 'f': '\f', 'r': '\r', 'e': '\x1b', '"': '"', '\\': '\\'}
+
+// parseEscape parses an escape sequence in a basic string
+func (p *tomlParser) parseEscape(sb *strings.Builder) error {
+	p.at++
+	if p.eof() {
+		return fmt.Errorf("unterminated string")
+	}
+	c := p.peek()
+	p.at++
+
+	if r, ok := tomlEscapes[c]; ok {
+		sb.WriteByte(r)
+		return nil
+	}
+
+	var size int
+	switch c {
+	case 'u':
+		size = 4
+	case 'U':
+		size = 8
+	default:
+		return fmt.Errorf("invalid escape sequence \\%c", c)
+	}
+	if p.at+size > len(p.src) {
+		return fmt.Errorf("invalid unicode escape")
+	}
+	code, err := strconv.ParseUint(p.src[p.at:p.at+size], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return fmt.Errorf("invalid unicode escape \\%c%s", c, p.src[p.at:p.at+size])
+	}
+	sb.WriteRune(rune(code))
+	p.at += size
+	return nil
+}
+
+// tomlDateWithSpace matches the start of a date-time using a space rather than a 'T' separator
+var tomlDateWithSpace = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:`)
+
+// parseScalar parses a boolean, number or date-time
+func (p *tomlParser) parseScalar() (any, error) {
+	start := p.at
+	if tomlDateWithSpace.MatchString(p.src[p.at:]) {
+		p.at += len("2006-01-02 ")
+	}
+	for !p.eof() && strings.IndexByte(" \t\r\n,]}#", p.peek()) < 0 {
+		p.at++
+	}
+	tok := p.src[start:p.at]
+
+	switch tok {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan", "+nan", "-nan":
+		return math.NaN(), nil
+	}
+
+	if v, ok := tomlNumber(tok); ok {
+		return v, nil
+	}
+	if v, ok := tomlDateTime(tok); ok {
+		return v, nil
+	}
+	if tok == "" {
+		return nil, fmt.Errorf("expected a value, found %q", p.peek())
+	}
+	return nil, fmt.Errorf("invalid value %q", tok)
+}
+
+// tomlNumber parses integers (including hex, octal and binary) and floats
+func tomlNumber(tok string) (any, bool) {
+	if strings.Contains(tok, "__") || strings.HasPrefix(tok, "_") || strings.HasSuffix(tok, "_") {
+		return nil, false
+	}
+	s := strings.ReplaceAll(tok, "_", "")
+
+	for prefix, base := range map[string]int{"0x": 16, "0o": 8, "0b": 2} {
+		if strings.HasPrefix(s, prefix) {
+			i, err := strconv.ParseInt(s[2:], base, 64)
+			return i, err == nil
+		}
+	}
+
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		digits := strings.TrimLeft(s, "+-")
+		if len(digits) > 1 && digits[0] == '0' {
+			return nil, false // leading zeros aren't allowed
+		}
+		return i, true
+	}
+
+	if strings.ContainsAny(s, ".eE") && !strings.ContainsAny(s, "xXpP") {
+		f, err := strconv.ParseFloat(s, 64)
+		return f, err == nil
+	}
+	return nil, false
+}
+
+// tomlLocalFormats are the layouts for dates and times without a time zone, which are left as strings
+var tomlLocalFormats = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999",
+}
+
+// tomlDateTime parses an offset date-time, or validates a local date and/or time
+func tomlDateTime(tok string) (any, bool) {
+	s := strings.ToUpper(strings.Replace(tok, " ", "T", 1))
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, true
+	}
+	for _, f := range tomlLocalFormats {
+		if _, err := time.Parse(f, s); err == nil {
+			return tok, true
+		}
+	}
+	return nil, false
+}
+
+// ToTOML returns the given dictionary as a TOML document, with keys in sorted order.
+// Whole numbers are written as integers, and nil values are left out as TOML has no equivalent
+func ToTOML(val any) (string, error) {
+	m, ok := plain(val).(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("a toml document must be a dictionary, not %T", val)
+	}
+
+	var sb strings.Builder
+	if err := writeTOMLTable(&sb, nil, m); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// writeTOMLTable writes the contents of a table. Simple values are written first,
+// as anything following a table header belongs to that table
+func writeTOMLTable(sb *strings.Builder, path []string, m map[string]any) error {
+	keys := sortedKeys(m)
+	for _, k := range keys {
+		v := m[k]
+		if v == nil || isTable(v) || isTableList(v) {
+			continue
+		}
+		s, err := tomlValue(v)
+		if err != nil {
+			return fmt.Errorf("key %q: %w", strings.Join(append(path, k), "."), err)
+		}
+		fmt.Fprintf(sb, "%s = %s\n", tomlKey(k), s)
+	}
+
+	for _, k := range keys {
+		p := append(path[:len(path):len(path)], k)
+		switch v := m[k].(type) {
+		case map[string]any:
+			if hasSimpleValues(v) || len(v) == 0 {
+				writeTOMLHeader(sb, "[", p, "]")
+			}
+			if err := writeTOMLTable(sb, p, v); err != nil {
+				return err
+			}
+		case []any:
+			if !isTableList(v) {
+				continue
+			}
+			for _, e := range v {
+				writeTOMLHeader(sb, "[[", p, "]]")
+				if err := writeTOMLTable(sb, p, e.(map[string]any)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func writeTOMLHeader(sb *strings.Builder, open string, path []string, closing string) {
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	keys := make([]string, len(path))
+	for i, k := range path {
+		keys[i] = tomlKey(k)
+	}
+	sb.WriteString(open + strings.Join(keys, ".") + closing + "\n")
+}
+
+// hasSimpleValues returns true if the table contains anything other than sub-tables
+func hasSimpleValues(m map[string]any) bool {
+	for _, v := range m {
+		if v != nil && !isTable(v) && !isTableList(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// tomlIntegerInRange returns false for integers that can't be written as toml, which only has signed 64 bit integers
+func tomlIntegerInRange(v any) bool {
+	switch n := v.(type) {
+	case uint64:
+		return n <= math.MaxInt64
+	case uint:
+		return uint64(n) <= math.MaxInt64
+	case json.Number:
+		if _, err := strconv.ParseInt(n.String(), 10, 64); errors.Is(err, strconv.ErrRange) {
+			return false
+		}
+	}
+	return true
+}
+
+// tomlValue formats a value for use on the right-hand side of a key/value pair
+func tomlValue(v any) (string, error) {
+	if n, ok := formatNumber(v); ok {
+		if !tomlIntegerInRange(v) {
+			return "", fmt.Errorf("toml integers must fit in 64 bits (signed), and %s doesn't", n)
+		}
+		return n, nil
+	}
+
+	switch t := v.(type) {
+	case nil:
+		return "", fmt.Errorf("toml has no equivalent of nil")
+	case string:
+		return tomlString(t), nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	case time.Time:
+		return t.Format(time.RFC3339Nano), nil
+	case []any:
+		items := make([]string, len(t))
+		for i, e := range t {
+			s, err := tomlValue(e)
+			if err != nil {
+				return "", err
+			}
+			items[i] = s
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]any:
+		var items []string
+		for _, k := range sortedKeys(t) {
+			if t[k] == nil {
+				continue
+			}
+			s, err := tomlValue(t[k])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, tomlKey(k)+" = "+s)
+		}
+		if len(items) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(items, ", ") + " }", nil
+	}
+	return tomlString(fmt.Sprint(v)), nil
+}
+
+// tomlKey quotes a key if it can't be used as a bare key
+func tomlKey(k string) string {
+	if k == "" {
+		return `""`
+	}
+	for i := 0; i < len(k); i++ {
+		if !isBareKeyChar(k[i]) {
+			return tomlString(k)
+		}
+	}
+	return k
+}
+
+// tomlString quotes and escapes a string
+func tomlString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u%04X`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
@@ -0,0 +1,189 @@
+package encoding
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFromTOML provides unit test coverage for FromTOML()
+func TestFromTOML(t *testing.T) {
+	got, err := FromTOML(`
+# This is a TOML document
+title = "TOML Example" # with a comment
+
+[owner]
+name = 'Tom Preston-Werner'
+dob = 1979-05-27T07:32:00-08:00
+
+[database]
+enabled = true
+ports = [ 8000, 8001, 8002 ]
+data = [ ["delta", "phi"], [3.14] ]
+temp_targets = { cpu = 79.5, case = 72.0 }
+
+[servers]
+
+[servers.alpha]
+ip = "10.0.0.1"
+role = "frontend"
+
+[servers."beta gamma"]
+ip = "10.0.0.2"
+role = "backend"
+
+[[products]]
+name = "Hammer"
+sku = 738594937
+
+[[products]]
+
+[[products]]
+name = "Nail"
+sku = 284758393
+colour.primary = "grey"
+
+[[products.variants]]
+size = "large"
+`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"title": "TOML Example",
+		"owner": map[string]any{
+			"name": "Tom Preston-Werner",
+			"dob":  time.Date(1979, 5, 27, 7, 32, 0, 0, time.FixedZone("", -8*60*60)),
+		},
+		"database": map[string]any{
+			"enabled":      true,
+			"ports":        []any{int64(8000), int64(8001), int64(8002)},
+			"data":         []any{[]any{"delta", "phi"}, []any{3.14}},
+			"temp_targets": map[string]any{"cpu": 79.5, "case": 72.0},
+		},
+		"servers": map[string]any{
+			"alpha":      map[string]any{"ip": "10.0.0.1", "role": "frontend"},
+			"beta gamma": map[string]any{"ip": "10.0.0.2", "role": "backend"},
+		},
+		"products": []any{
+			map[string]any{"name": "Hammer", "sku": int64(738594937)},
+			map[string]any{},
+			map[string]any{
+				"name":     "Nail",
+				"sku":      int64(284758393),
+				"colour":   map[string]any{"primary": "grey"},
+				"variants": []any{map[string]any{"size": "large"}},
+			},
+		},
+	}, got)
+}
+
+// TestFromTOMLValues provides unit test coverage for the values parsed by FromTOML()
+func TestFromTOMLValues(t *testing.T) {
+	tests := []struct {
+		name string
+		toml string
+		want any
+	}{
+		{name: "basic string escapes", toml: `v = "tab\there \"quoted\" \u00e9\U0001F600 back\\slash"`, want: "tab\there \"quoted\" é😀 back\\slash"},
+		{name: "literal string", toml: `v = 'C:\Users\nodejs'`, want: `C:\Users\nodejs`},
+		{name: "multiline basic", toml: "v = \"\"\"\nRoses are red\nViolets are blue\"\"\"", want: "Roses are red\nViolets are blue"},
+		{name: "line ending backslash", toml: "v = \"\"\"\nThe quick brown \\\n\n    fox.\"\"\"", want: "The quick brown fox."},
+		{name: "multiline quotes", toml: `v = """Here are two quotation marks: "". Simple enough."""""`, want: `Here are two quotation marks: "". Simple enough.""`},
+		{name: "multiline literal", toml: "v = '''\nThe first newline is\ntrimmed in raw strings.\n   \\n stays'''", want: "The first newline is\ntrimmed in raw strings.\n   \\n stays"},
+		{name: "integer", toml: "v = +1_000", want: int64(1000)},
+		{name: "negative", toml: "v = -17", want: int64(-17)},
+		{name: "hex", toml: "v = 0xDEAD_BEEF", want: int64(0xdeadbeef)},
+		{name: "octal", toml: "v = 0o755", want: int64(0o755)},
+		{name: "binary", toml: "v = 0b1101", want: int64(13)},
+		{name: "float", toml: "v = 6.626e-34", want: 6.626e-34},
+		{name: "float with underscores", toml: "v = 224_617.445_991", want: 224617.445991},
+		{name: "infinity", toml: "v = -inf", want: math.Inf(-1)},
+		{name: "false", toml: "v = false", want: false},
+		{name: "date-time with space", toml: "v = 1979-05-27 07:32:00Z", want: time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC)},
+		{name: "local date-time", toml: "v = 1979-05-27T07:32:00.999", want: "1979-05-27T07:32:00.999"},
+		{name: "local date", toml: "v = 1979-05-27", want: "1979-05-27"},
+		{name: "local time", toml: "v = 07:32:00", want: "07:32:00"},
+		{name: "multiline array", toml: "v = [\n  1, # one\n  2,\n]", want: []any{int64(1), int64(2)}},
+		{name: "empty inline table", toml: "v = {}", want: map[string]any{}},
+		{name: "nested inline table", toml: "v = { a.b = 1, c = { d = 'e' } }", want: map[string]any{"a": map[string]any{"b": int64(1)}, "c": map[string]any{"d": "e"}}},
+		{name: "table within array of tables", toml: "[[v]]\na = 1\n[v.b]\nc = 2\n[[v]]", want: []any{map[string]any{"a": int64(1), "b": map[string]any{"c": int64(2)}}, map[string]any{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromTOML(tt.toml)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got["v"])
+		})
+	}
+
+	got, err := FromTOML("v = nan")
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(got["v"].(float64)))
+}
+
+// TestFromTOMLErrors provides unit test coverage for invalid documents given to FromTOML()
+func TestFromTOMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		toml string
+	}{
+		{name: "missing value", toml: "a ="},
+		{name: "missing equals", toml: "a 1"},
+		{name: "missing key", toml: "= 1"},
+		{name: "duplicate key", toml: "a = 1\na = 2"},
+		{name: "duplicate table", toml: "[a]\n[a]"},
+		{name: "table over value", toml: "a = 1\n[a.b]"},
+		{name: "array table over value", toml: "a = 1\n[[a]]"},
+		{name: "array table over table", toml: "[a]\n[[a]]"},
+		{name: "table in static array", toml: "a = [1]\n[a.b]"},
+		{name: "key in empty array", toml: "a = []\na.b = 1"},
+		{name: "table in empty array", toml: "a = []\n[a.b]"},
+		{name: "array table over static array", toml: "a = [{ b = 1 }]\n[[a]]"},
+		{name: "table over inline table", toml: "a = { b = 1 }\n[a]"},
+		{name: "sub-table of inline table", toml: "a = { b = { c = 1 } }\n[a.b.d]"},
+		{name: "key in inline table", toml: "a = { b = 1 }\na.c = 2"},
+		{name: "key in nested inline table", toml: "a = { b = { c = 1 }, b.d = 2 }"},
+		{name: "unterminated table", toml: "[a"},
+		{name: "unterminated array table", toml: "[[a]"},
+		{name: "unterminated string", toml: `a = "abc`},
+		{name: "string over newline", toml: "a = \"abc\n\""},
+		{name: "unterminated literal", toml: "a = 'abc"},
+		{name: "unterminated multiline", toml: `a = """abc`},
+		{name: "bad escape", toml: `a = "\q"`},
+		{name: "bad unicode escape", toml: `a = "\uZZZZ"`},
+		{name: "short unicode escape", toml: `a = "\u12"`},
+		{name: "unterminated escape", toml: `a = "\`},
+		{name: "unterminated array", toml: "a = [1, 2"},
+		{name: "missing comma", toml: "a = [1 2]"},
+		{name: "bad inline table", toml: "a = { b = 1 c = 2 }"},
+		{name: "bad inline key", toml: "a = { = 1 }"},
+		{name: "leading zero", toml: "a = 012"},
+		{name: "double underscore", toml: "a = 1__2"},
+		{name: "bad value", toml: "a = yes"},
+		{name: "bad hex", toml: "a = 0xZZ"},
+		{name: "trailing text", toml: "a = 1 b = 2"},
+		{name: "empty value", toml: "a = ,"},
+		{name: "key at end", toml: "a."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromTOML(tt.toml)
+			assert.Error(t, err)
+		})
+	}
+
+	test := helper.TestSet{
+		Name:     "template",
+		Template: `{{ with fromTOML .toml }}{{ .server.host }}:{{ .server.port }}{{ end }}`,
+		Args: helper.TestArgs{
+			"toml": "[server]\nhost = \"localhost\"\nport = 8080\n",
+		},
+		Want: "localhost:8080",
+	}
+	t.Run(test.Name, helper.TemplateTest(test, Functions()))
+}
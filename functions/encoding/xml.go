@@ -0,0 +1,176 @@
+package encoding
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// xmlName matches valid (non-namespaced) element and attribute names
+var xmlName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// xmlEncoder holds the settings for encoding a value as xml
+type xmlEncoder struct {
+	sb         strings.Builder
+	attrPrefix string
+	textKey    string
+	item       string
+	indent     string
+}
+
+// ToXML returns the given value as an xml document, using the default options of ToXMLWith
+func ToXML(val any) (string, error) {
+	return ToXMLWith(nil, val)
+}
+
+// ToXMLWith returns the given value as an xml document.
+//
+// Dictionary keys become elements, in sorted order, and lists become repeated elements.
+// The options (a dictionary) are:
+//
+//	root       - the name of the document element (default "root")
+//	attrPrefix - keys with this prefix become attributes of their element (default "@")
+//	textKey    - the key for the text of an element that also has attributes (default "#text")
+//	item       - the name of elements in lists that aren't the value of a key (default "item")
+//	indent     - the indent for each level of nesting, with "" putting everything on a single line (default "  ")
+//	header     - whether to start with an xml declaration (default true)
+func ToXMLWith(opts any, val any) (string, error) {
+	o, err := helper.NewOptions(opts, "root", "attrPrefix", "textKey", "item", "indent", "header")
+	if err != nil {
+		return "", err
+	}
+
+	e := &xmlEncoder{}
+	root, err := o.String("root", "root")
+	if err != nil {
+		return "", err
+	}
+	if e.attrPrefix, err = o.String("attrPrefix", "@"); err != nil {
+		return "", err
+	}
+	if e.textKey, err = o.String("textKey", "#text"); err != nil {
+		return "", err
+	}
+	if e.item, err = o.String("item", "item"); err != nil {
+		return "", err
+	}
+	if e.indent, err = o.String("indent", "  "); err != nil {
+		return "", err
+	}
+	header, err := o.Bool("header", true)
+	if err != nil {
+		return "", err
+	}
+
+	if header {
+		e.sb.WriteString(xml.Header)
+	}
+	if err = e.element(root, plain(val), 0); err != nil {
+		return "", err
+	}
+	if e.indent != "" {
+		e.sb.WriteString("\n")
+	}
+	return e.sb.String(), nil
+}
+
+func (e *xmlEncoder) newline(depth int) {
+	if e.indent != "" {
+		e.sb.WriteString("\n" + strings.Repeat(e.indent, depth))
+	}
+}
+
+func (e *xmlEncoder) element(name string, v any, depth int) error {
+	if !xmlName.MatchString(name) {
+		return fmt.Errorf("%q can't be used as an xml element name", name)
+	}
+	e.sb.WriteString("<" + name)
+
+	var children []string
+	var text any
+	switch t := v.(type) {
+	case nil:
+		e.sb.WriteString("/>")
+		return nil
+
+	case map[string]any:
+		for _, k := range sortedKeys(t) {
+			switch {
+			case k == e.textKey:
+				text = t[k]
+			case e.attrPrefix != "" && strings.HasPrefix(k, e.attrPrefix):
+				if err := e.attribute(strings.TrimPrefix(k, e.attrPrefix), t[k]); err != nil {
+					return err
+				}
+			default:
+				children = append(children, k)
+			}
+		}
+		if text == nil && len(children) == 0 {
+			e.sb.WriteString("/>")
+			return nil
+		}
+		e.sb.WriteString(">")
+		e.text(text)
+		for _, k := range children {
+			if err := e.child(k, t[k], depth+1); err != nil {
+				return err
+			}
+		}
+
+	case []any:
+		e.sb.WriteString(">")
+		for _, i := range t {
+			e.newline(depth + 1)
+			if err := e.element(e.item, i, depth+1); err != nil {
+				return err
+			}
+		}
+		children = []string{e.item}
+
+	default:
+		e.sb.WriteString(">")
+		e.text(t)
+	}
+
+	if len(children) > 0 {
+		e.newline(depth)
+	}
+	e.sb.WriteString("</" + name + ">")
+	return nil
+}
+
+// child writes the value of a key as an element, or for lists, as repeated elements
+func (e *xmlEncoder) child(name string, v any, depth int) error {
+	l, ok := v.([]any)
+	if !ok {
+		e.newline(depth)
+		return e.element(name, v, depth)
+	}
+	for _, i := range l {
+		e.newline(depth)
+		if err := e.element(name, i, depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *xmlEncoder) attribute(name string, v any) error {
+	if !xmlName.MatchString(name) {
+		return fmt.Errorf("%q can't be used as an xml attribute name", name)
+	}
+	e.sb.WriteString(" " + name + `="`)
+	e.text(v)
+	e.sb.WriteString(`"`)
+	return nil
+}
+
+func (e *xmlEncoder) text(v any) {
+	if v != nil {
+		_ = xml.EscapeText(&e.sb, []byte(scalarString(v)))
+	}
+}
@@ -1,9 +1,163 @@
 package encoding
 
-import "gopkg.in/yaml.v2"
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
 
 // ToYAML returns the given value as a yaml string
 func ToYAML(val any) (string, error) {
 	b, err := yaml.Marshal(val)
 	return string(b), err
 }
+
+// yamlStyles holds the settings for styling the nodes of a yaml document
+type yamlStyles struct {
+	multiline string
+	flow      string
+}
+
+// ToYAMLWith returns the given value as a yaml string, formatted according to the options (a dictionary):
+//
+//	indent          - the number of spaces for each level of nesting, from 2 to 9 (default 2)
+//	multiline       - the style of strings containing newlines, one of "literal", "folded" or "quoted" (default "literal")
+//	flow            - which lists and dictionaries are written in flow style, eg [a, b],
+//	                  one of "never", "scalars" (those only containing simple values) or "always" (default "never")
+//	keepOrder       - treat a string value as a yaml (or json) document, which is reformatted keeping the
+//	                  order of its keys, rather than sorting them (default false)
+//	documents       - write each item of a list as a separate document (default false)
+//	trailingNewline - end with a newline (default true)
+func ToYAMLWith(opts any, val any) (string, error) {
+	o, err := helper.NewOptions(opts, "indent", "multiline", "flow", "keepOrder", "documents", "trailingNewline")
+	if err != nil {
+		return "", err
+	}
+
+	indent, err := o.Int("indent", 2)
+	if err != nil {
+		return "", err
+	}
+	if indent < 2 || indent > 9 {
+		return "", fmt.Errorf("option \"indent\" should be from 2 to 9, not %d", indent)
+	}
+	s := yamlStyles{}
+	if s.multiline, err = o.String("multiline", "literal"); err != nil {
+		return "", err
+	}
+	if s.multiline != "literal" && s.multiline != "folded" && s.multiline != "quoted" {
+		return "", fmt.Errorf("option \"multiline\" should be one of literal, folded or quoted, not %q", s.multiline)
+	}
+	if s.flow, err = o.String("flow", "never"); err != nil {
+		return "", err
+	}
+	if s.flow != "never" && s.flow != "scalars" && s.flow != "always" {
+		return "", fmt.Errorf("option \"flow\" should be one of never, scalars or always, not %q", s.flow)
+	}
+	keepOrder, err := o.Bool("keepOrder", false)
+	if err != nil {
+		return "", err
+	}
+	documents, err := o.Bool("documents", false)
+	if err != nil {
+		return "", err
+	}
+	trailingNewline, err := o.Bool("trailingNewline", true)
+	if err != nil {
+		return "", err
+	}
+
+	root, err := yamlNode(val, keepOrder)
+	if err != nil {
+		return "", err
+	}
+	docs := []*yamlv3.Node{root}
+	if documents && root.Kind == yamlv3.SequenceNode {
+		docs = root.Content
+	}
+
+	var buf bytes.Buffer
+	enc := yamlv3.NewEncoder(&buf)
+	enc.SetIndent(indent)
+	for _, d := range docs {
+		s.apply(d)
+		if err = enc.Encode(d); err != nil {
+			return "", err
+		}
+	}
+	if err = enc.Close(); err != nil {
+		return "", err
+	}
+
+	res := buf.String()
+	if !trailingNewline {
+		res = strings.TrimSuffix(res, "\n")
+	}
+	return res, nil
+}
+
+// yamlNode converts a value to a yaml node, or when keeping the order, parses a document
+func yamlNode(val any, keepOrder bool) (*yamlv3.Node, error) {
+	n := &yamlv3.Node{}
+	if doc, ok := val.(string); ok && keepOrder {
+		if err := yamlv3.Unmarshal([]byte(doc), n); err != nil {
+			return nil, fmt.Errorf("failed to parse yaml: %w", err)
+		}
+		if len(n.Content) == 0 {
+			return &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!null", Value: "null"}, nil
+		}
+		return n.Content[0], nil
+	}
+
+	if err := n.Encode(val); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// apply sets the style of the node and its children
+func (s yamlStyles) apply(n *yamlv3.Node) {
+	switch n.Kind {
+	case yamlv3.ScalarNode:
+		if n.ShortTag() != "!!str" {
+			return
+		}
+		n.Tag = "!!str"
+		n.Style = 0 // quoted where necessary by the encoder
+		if strings.Contains(n.Value, "\n") {
+			switch s.multiline {
+			case "literal":
+				n.Style = yamlv3.LiteralStyle
+			case "folded":
+				n.Style = yamlv3.FoldedStyle
+			case "quoted":
+				n.Style = yamlv3.DoubleQuotedStyle
+			}
+		}
+		return
+
+	case yamlv3.MappingNode, yamlv3.SequenceNode:
+		n.Style = 0
+		if s.flow == "always" || (s.flow == "scalars" && onlyScalars(n)) {
+			n.Style = yamlv3.FlowStyle
+		}
+	}
+
+	for _, c := range n.Content {
+		s.apply(c)
+	}
+}
+
+// onlyScalars returns true if the node only contains simple values
+func onlyScalars(n *yamlv3.Node) bool {
+	for _, c := range n.Content {
+		if c.Kind != yamlv3.ScalarNode && c.Kind != yamlv3.AliasNode {
+			return false
+		}
+	}
+	return true
+}
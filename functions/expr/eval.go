@@ -0,0 +1,387 @@
+package expr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/mantidtech/tplr/functions/logic"
+)
+
+// env is the environment an expression is evaluated in
+type env struct {
+	data any
+	fns  template.FuncMap
+}
+
+// node is an element of a parsed expression
+type node interface {
+	eval(e *env) (any, error)
+}
+
+// literalNode is a constant value
+type literalNode struct {
+	value any
+}
+
+func (n literalNode) eval(*env) (any, error) {
+	return n.value, nil
+}
+
+// variableNode is a top-level value taken from the data
+type variableNode struct {
+	name string
+}
+
+func (n variableNode) eval(e *env) (any, error) {
+	v, ok := helper.Field(e.data, n.name)
+	if !ok {
+		return nil, fmt.Errorf("unknown variable %q", n.name)
+	}
+	return v, nil
+}
+
+// indexNode looks up a key or index of a value. Missing keys result in nil
+type indexNode struct {
+	target node
+	index  node
+}
+
+func (n indexNode) eval(e *env) (any, error) {
+	t, err := n.target.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	i, err := n.index.eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	if s, ok := i.(string); ok {
+		v, _ := helper.Field(t, s)
+		return v, nil
+	}
+	idx, err := helper.ToInt(i)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index: %w", err)
+	}
+	v, _ := helper.Index(t, int(idx))
+	return v, nil
+}
+
+// unaryNode applies a prefix operator
+type unaryNode struct {
+	op      string
+	operand node
+}
+
+func (n unaryNode) eval(e *env) (any, error) {
+	v, err := n.operand.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "!" {
+		return !truthy(v), nil
+	}
+	f, err := helper.ToFloat(v)
+	if err != nil {
+		return nil, err
+	}
+	return -f, nil
+}
+
+// ternaryNode chooses between two values based on a condition
+type ternaryNode struct {
+	cond, yes, no node
+}
+
+func (n ternaryNode) eval(e *env) (any, error) {
+	c, err := n.cond.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	if truthy(c) {
+		return n.yes.eval(e)
+	}
+	return n.no.eval(e)
+}
+
+// binaryNode applies an infix operator
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n binaryNode) eval(e *env) (any, error) {
+	l, err := n.left.eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	// logical operators short-circuit
+	switch n.op {
+	case "&&":
+		if !truthy(l) {
+			return false, nil
+		}
+		r, errR := n.right.eval(e)
+		return truthy(r), errR
+	case "||":
+		if truthy(l) {
+			return true, nil
+		}
+		r, errR := n.right.eval(e)
+		return truthy(r), errR
+	}
+
+	r, err := n.right.eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(l, r), nil
+	case "!=":
+		return !equal(l, r), nil
+	case "<", "<=", ">", ">=":
+		return compare(n.op, l, r)
+	case "+":
+		if isString(l) || isString(r) {
+			return fmt.Sprint(l) + fmt.Sprint(r), nil
+		}
+	}
+
+	return arithmetic(n.op, l, r)
+}
+
+// callNode calls a function from the template function map
+type callNode struct {
+	name string
+	args []node
+	pos  int
+}
+
+func (n callNode) eval(e *env) (any, error) {
+	fn, ok := e.fns[n.name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", n.name, n.pos)
+	}
+
+	args := make([]any, len(n.args))
+	for i, a := range n.args {
+		var err error
+		args[i], err = a.eval(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := call(fn, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", n.name, err)
+	}
+	return res, nil
+}
+
+// truthy uses the same definition of truth as the isZero function
+func truthy(v any) bool {
+	return !logic.IsZero(v)
+}
+
+// isString returns true for string values (but not json numbers)
+func isString(v any) bool {
+	if _, ok := v.(json.Number); ok {
+		return false
+	}
+	return v != nil && reflect.TypeOf(v).Kind() == reflect.String
+}
+
+// isNumber returns true for numeric types
+func isNumber(v any) bool {
+	if _, ok := v.(json.Number); ok {
+		return true
+	}
+	if v == nil {
+		return false
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// equal compares two values, treating all numeric types as equivalent
+func equal(l, r any) bool {
+	if isNumber(l) && isNumber(r) {
+		a, errA := helper.ToFloat(l)
+		b, errB := helper.ToFloat(r)
+		return errA == nil && errB == nil && a == b
+	}
+	return reflect.DeepEqual(l, r)
+}
+
+// compare orders two strings lexically, or anything else numerically
+func compare(op string, l, r any) (bool, error) {
+	var c int
+	if isString(l) && isString(r) {
+		c = strings.Compare(fmt.Sprint(l), fmt.Sprint(r))
+	} else {
+		a, err := helper.ToFloat(l)
+		if err != nil {
+			return false, fmt.Errorf("can't compare %T and %T", l, r)
+		}
+		b, err := helper.ToFloat(r)
+		if err != nil {
+			return false, fmt.Errorf("can't compare %T and %T", l, r)
+		}
+		switch {
+		case a < b:
+			c = -1
+		case a > b:
+			c = 1
+		}
+	}
+
+	switch op {
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	}
+	return c >= 0, nil
+}
+
+// arithmetic applies a numeric operator
+func arithmetic(op string, l, r any) (any, error) {
+	a, err := helper.ToFloat(l)
+	if err != nil {
+		return nil, err
+	}
+	b, err := helper.ToFloat(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		if b == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return a / b, nil
+	}
+
+	if b == 0 {
+		return nil, errors.New("modulo by zero")
+	}
+	return math.Mod(a, b), nil
+}
+
+// errorType is the reflected type of the error interface
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// call invokes a function with the given arguments in the same way that templates do,
+// converting numbers to the types the function expects
+func call(fn any, args []any) (any, error) {
+	v := reflect.ValueOf(fn)
+	t := v.Type()
+	if t.Kind() != reflect.Func {
+		return nil, fmt.Errorf("not a function")
+	}
+
+	numIn := t.NumIn()
+	if t.IsVariadic() {
+		if len(args) < numIn-1 {
+			return nil, fmt.Errorf("wrong number of args: want at least %d, got %d", numIn-1, len(args))
+		}
+	} else if len(args) != numIn {
+		return nil, fmt.Errorf("wrong number of args: want %d, got %d", numIn, len(args))
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, a := range args {
+		var pt reflect.Type
+		if t.IsVariadic() && i >= numIn-1 {
+			pt = t.In(numIn - 1).Elem()
+		} else {
+			pt = t.In(i)
+		}
+
+		var err error
+		in[i], err = convertArg(a, pt)
+		if err != nil {
+			return nil, fmt.Errorf("arg %d: %w", i, err)
+		}
+	}
+
+	out := v.Call(in)
+	if len(out) == 2 && t.Out(1) == errorType && !out[1].IsNil() {
+		return nil, out[1].Interface().(error)
+	}
+	return out[0].Interface(), nil
+}
+
+// convertArg converts a value to the type of a function parameter
+func convertArg(a any, pt reflect.Type) (reflect.Value, error) {
+	if a == nil {
+		switch pt.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return reflect.Zero(pt), nil
+		}
+		return reflect.Value{}, fmt.Errorf("can't use nil as %s", pt)
+	}
+
+	av := reflect.ValueOf(a)
+	if av.Type().AssignableTo(pt) {
+		return av, nil
+	}
+
+	switch pt.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := helper.ToInt(a)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if reflect.Zero(pt).OverflowInt(i) {
+			return reflect.Value{}, fmt.Errorf("%d overflows %s", i, pt)
+		}
+		return reflect.ValueOf(i).Convert(pt), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := helper.ToInt(a)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if i < 0 || reflect.Zero(pt).OverflowUint(uint64(i)) {
+			return reflect.Value{}, fmt.Errorf("%d overflows %s", i, pt)
+		}
+		return reflect.ValueOf(uint64(i)).Convert(pt), nil
+	case reflect.Float32, reflect.Float64:
+		f, err := helper.ToFloat(a)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(f).Convert(pt), nil
+	case reflect.String:
+		if isString(a) {
+			return av.Convert(pt), nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("can't use %T as %s", a, pt)
+}
@@ -0,0 +1,49 @@
+// Package expr provides an infix expression evaluator for templates
+package expr
+
+import (
+	"fmt"
+	"sync"
+	"text/template"
+)
+
+// Functions that evaluate expressions. Functions in fns can be called from within expressions
+func Functions(fns template.FuncMap) template.FuncMap {
+	return template.FuncMap{
+		"expr": GenerateExprFn(fns),
+	}
+}
+
+// GenerateExprFn creates a function to be used as an "expr" function in templates.
+//
+// Expressions support numbers, strings, true/false/nil, variables from the (optional) data argument,
+// arithmetic (+ - * / %), comparison (== != < <= > >=), boolean logic (&& || !), string concatenation (+),
+// the conditional operator (cond ? a : b), indexing (a.b, a[0], a["b"]),
+// and calls to the functions in fns (eg upper(name)). No other code can be executed
+func GenerateExprFn(fns template.FuncMap) func(string, ...any) (any, error) {
+	var cache sync.Map // parsed expressions, keyed by their source
+	return func(expression string, data ...any) (any, error) {
+		var n node
+		if c, ok := cache.Load(expression); ok {
+			n = c.(node)
+		} else {
+			var err error
+			n, err = parse(expression)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse expression %q: %w", expression, err)
+			}
+			cache.Store(expression, n)
+		}
+
+		e := &env{fns: fns}
+		if len(data) > 0 {
+			e.data = data[0]
+		}
+
+		res, err := n.eval(e)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate expression %q: %w", expression, err)
+		}
+		return res, nil
+	}
+}
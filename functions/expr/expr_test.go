@@ -0,0 +1,295 @@
+package expr
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+// testFunctions are made available to expressions under test
+var testFunctions = template.FuncMap{
+	"upper": strings.ToUpper,
+	"rep":   func(n int, s string) string { return strings.Repeat(s, n) },
+	"sum": func(o ...float64) float64 {
+		t := 0.0
+		for _, f := range o {
+			t += f
+		}
+		return t
+	},
+	"fail":  func() (string, error) { return "", errors.New("failed") },
+	"bytes": func(u uint8) uint8 { return u },
+	"list":  func(l []any) int { return len(l) },
+}
+
+// TestFunctions provides unit test coverage for Functions
+func TestFunctions(t *testing.T) {
+	fn := Functions(nil)
+	assert.Len(t, fn, 1, "weakly ensuring functions haven't been added/removed without updating tests")
+}
+
+// TestExpr provides unit test coverage for the function generated by GenerateExprFn()
+func TestExpr(t *testing.T) {
+	var items []any
+	_ = json.Unmarshal([]byte(`[{"name": "a", "cost": 1.5}, {"name": "b", "cost": 2}]`), &items)
+
+	data := helper.TestArgs{
+		"a":     1,
+		"b":     2.5,
+		"c":     int64(3),
+		"s":     "str",
+		"n":     json.Number("10"),
+		"t":     true,
+		"nope":  nil,
+		"items": items,
+		"m":     map[string]any{"k": map[string]any{"v": "deep"}},
+	}
+
+	tests := []helper.TestSet{
+		{
+			Name:     "literal",
+			Template: `{{ expr "42" }}`,
+			Want:     "42",
+		},
+		{
+			Name:     "precedence",
+			Template: `{{ expr "(a + b*2) / c" . }}`,
+			Args:     data,
+			Want:     "2",
+		},
+		{
+			Name:     "unary",
+			Template: `{{ expr "-a - -b" . }}`,
+			Args:     data,
+			Want:     "1.5",
+		},
+		{
+			Name:     "modulo",
+			Template: `{{ expr "7 % 3 + n % 4" . }}`,
+			Args:     data,
+			Want:     "3",
+		},
+		{
+			Name:     "exponent literal",
+			Template: `{{ expr "1.5e3 + 2E-1" }}`,
+			Want:     "1500.2",
+		},
+		{
+			Name:     "concatenation",
+			Template: `{{ expr "s + '-' + a" . }}`,
+			Args:     data,
+			Want:     "str-1",
+		},
+		{
+			Name:     "string escapes",
+			Template: `{{ expr "\"a\\tb\" + ` + "`" + `\\n` + "`" + `" }}`,
+			Want:     "a\tb\\n",
+		},
+		{
+			Name:     "comparison",
+			Template: `{{ expr "a < b && b <= 2.5 && c > a && c >= n - 7" . }}`,
+			Args:     data,
+			Want:     "true",
+		},
+		{
+			Name:     "string comparison",
+			Template: `{{ expr "'abc' < 'abd'" }}`,
+			Want:     "true",
+		},
+		{
+			Name:     "equality across number types",
+			Template: `{{ expr "a == 1 && c == 3 && n == 10 && s != 'x' && nope == nil" . }}`,
+			Args:     data,
+			Want:     "true",
+		},
+		{
+			Name:     "boolean logic",
+			Template: `{{ expr "!t || (false || s)" . }}`,
+			Args:     data,
+			Want:     "true",
+		},
+		{
+			Name:     "short circuit",
+			Template: `{{ expr "false && missing || true || missing" . }}`,
+			Args:     data,
+			Want:     "true",
+		},
+		{
+			Name:     "ternary",
+			Template: `{{ expr "a > 1 ? 'big' : b > 2 ? 'medium' : 'small'" . }}`,
+			Args:     data,
+			Want:     "medium",
+		},
+		{
+			Name:     "indexing",
+			Template: `{{ expr "items[1].cost + items[0]['cost'] + items[-1].cost" . }}`,
+			Args:     data,
+			Want:     "5.5",
+		},
+		{
+			Name:     "nested keys",
+			Template: `{{ expr "m.k.v + m.k.missing" . }}`,
+			Args:     data,
+			Want:     "deep<nil>",
+		},
+		{
+			Name:     "function calls",
+			Template: `{{ expr "upper(rep(a + 1, s)) + sum() + sum(a, b, c)" . }}`,
+			Args:     data,
+			Want:     "STRSTR06.5",
+		},
+		{
+			Name:     "unicode identifier",
+			Template: `{{ expr "größe * 2" . }}`,
+			Args:     helper.TestArgs{"größe": 4},
+			Want:     "8",
+		},
+		{
+			Name:     "function with nil arg",
+			Template: `{{ expr "list(nil)" }}`,
+			Want:     "0",
+		},
+		{
+			Name:     "function error",
+			Template: `{{ expr "fail()" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "unknown function",
+			Template: `{{ expr "exec('rm')" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "wrong arg count",
+			Template: `{{ expr "upper()" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "too few variadic args",
+			Template: `{{ expr "rep()" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "wrong arg type",
+			Template: `{{ expr "upper(1)" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "non-integer arg",
+			Template: `{{ expr "rep(1.5, 'x')" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "uint overflow",
+			Template: `{{ expr "bytes(256)" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "nil for string",
+			Template: `{{ expr "upper(nil)" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "unknown variable",
+			Template: `{{ expr "missing + 1" . }}`,
+			Args:     data,
+			WantErr:  true,
+		},
+		{
+			Name:     "division by zero",
+			Template: `{{ expr "1 / 0" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "modulo by zero",
+			Template: `{{ expr "1 % 0" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad arithmetic",
+			Template: `{{ expr "items * 2" . }}`,
+			Args:     data,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad negation",
+			Template: `{{ expr "-items" . }}`,
+			Args:     data,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad comparison",
+			Template: `{{ expr "items < 2" . }}`,
+			Args:     data,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad index",
+			Template: `{{ expr "items[m]" . }}`,
+			Args:     data,
+			WantErr:  true,
+		},
+		{
+			Name:     "syntax error",
+			Template: `{{ expr "(1 + 2" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "trailing tokens",
+			Template: `{{ expr "1 2" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "unexpected character",
+			Template: `{{ expr "1 # 2" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "unterminated string",
+			Template: `{{ expr "'abc" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad number",
+			Template: `{{ expr "1.2.3" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "incomplete ternary",
+			Template: `{{ expr "t ? 1" . }}`,
+			Args:     data,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad member",
+			Template: `{{ expr "m.1" . }}`,
+			Args:     data,
+			WantErr:  true,
+		},
+		{
+			Name:     "empty",
+			Template: `{{ expr "" }}`,
+			WantErr:  true,
+		},
+	}
+
+	fns := helper.Combine(testFunctions, Functions(testFunctions))
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, fns))
+	}
+}
+
+// TestExprCache ensures that repeated evaluations of an expression give consistent results
+func TestExprCache(t *testing.T) {
+	fn := GenerateExprFn(nil)
+	for i := 0; i < 3; i++ {
+		got, err := fn("a * 2", map[string]any{"a": i})
+		assert.NoError(t, err)
+		assert.Equal(t, float64(i*2), got)
+	}
+}
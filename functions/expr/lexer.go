@@ -0,0 +1,135 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind identifies the type of token
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOperator
+)
+
+// token is a lexical element of an expression
+type token struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+// operators are listed longest first so that eg "<=" is matched before "<"
+var operators = []string{
+	"&&", "||", "==", "!=", "<=", ">=",
+	"+", "-", "*", "/", "%", "<", ">", "!", "(", ")", "[", "]", ",", ".", "?", ":",
+}
+
+// tokenize splits an expression into tokens
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	at := 0
+
+	for at < len(src) {
+		r, size := utf8.DecodeRuneInString(src[at:])
+		switch {
+		case unicode.IsSpace(r):
+			at += size
+
+		case r >= '0' && r <= '9':
+			end := at
+			for end < len(src) && (isDigit(src[end]) || src[end] == '.') {
+				end++
+			}
+			if end < len(src) && (src[end] == 'e' || src[end] == 'E') {
+				end++
+				if end < len(src) && (src[end] == '+' || src[end] == '-') {
+					end++
+				}
+				for end < len(src) && isDigit(src[end]) {
+					end++
+				}
+			}
+			f, err := strconv.ParseFloat(src[at:end], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", src[at:end], at)
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[at:end], value: f, pos: at})
+			at = end
+
+		case r == '"' || r == '\'' || r == '`':
+			s, end, err := readString(src, at)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokString, text: src[at:end], value: s, pos: at})
+			at = end
+
+		case r == '_' || unicode.IsLetter(r):
+			end := at
+			for end < len(src) {
+				c, sz := utf8.DecodeRuneInString(src[end:])
+				if c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+					break
+				}
+				end += sz
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[at:end], pos: at})
+			at = end
+
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(src[at:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at position %d", r, at)
+			}
+			tokens = append(tokens, token{kind: tokOperator, text: op, pos: at})
+			at += len(op)
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// readString reads a quoted string starting at position at, returning its value and the position after it.
+// Double-quoted strings support the usual Go escapes, single and back-quoted strings are taken literally
+func readString(src string, at int) (string, int, error) {
+	q := src[at]
+	end := at + 1
+	for end < len(src) && src[end] != q {
+		if src[end] == '\\' && q == '"' {
+			end++
+		}
+		end++
+	}
+	if end >= len(src) {
+		return "", 0, fmt.Errorf("unterminated string at position %d", at)
+	}
+	end++
+
+	if q != '"' {
+		return src[at+1 : end-1], end, nil
+	}
+	s, err := strconv.Unquote(src[at:end])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid string %s at position %d", src[at:end], at)
+	}
+	return s, end, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
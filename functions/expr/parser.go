@@ -0,0 +1,234 @@
+package expr
+
+import (
+	"fmt"
+)
+
+// parser is a recursive descent parser for expressions.
+//
+// In order of increasing precedence, the grammar is:
+//
+//	ternary    := or ( "?" ternary ":" ternary )?
+//	or         := and ( "||" and )*
+//	and        := equality ( "&&" equality )*
+//	equality   := comparison ( ( "==" | "!=" ) comparison )*
+//	comparison := additive ( ( "<" | "<=" | ">" | ">=" ) additive )*
+//	additive   := term ( ( "+" | "-" ) term )*
+//	term       := unary ( ( "*" | "/" | "%" ) unary )*
+//	unary      := ( "!" | "-" ) unary | postfix
+//	postfix    := primary ( "." ident | "[" ternary "]" )*
+//	primary    := number | string | "true" | "false" | "nil" | ident | ident "(" args ")" | "(" ternary ")"
+type parser struct {
+	tokens []token
+	at     int
+}
+
+// parse converts the expression into an evaluable tree
+func parse(src string) (node, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	n, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.at]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.at]
+	if t.kind != tokEOF {
+		p.at++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the given operators
+func (p *parser) accept(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokOperator {
+		return "", false
+	}
+	for _, o := range ops {
+		if t.text == o {
+			p.at++
+			return o, true
+		}
+	}
+	return "", false
+}
+
+// expect consumes the given operator, or returns an error if it isn't next
+func (p *parser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		t := p.peek()
+		if t.kind == tokEOF {
+			return fmt.Errorf("expected %q at end of expression", op)
+		}
+		return fmt.Errorf("expected %q at position %d, got %q", op, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *parser) parseTernary() (node, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("?"); !ok {
+		return cond, nil
+	}
+
+	yes, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(":"); err != nil {
+		return nil, err
+	}
+	no, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	return ternaryNode{cond: cond, yes: yes, no: no}, nil
+}
+
+// precedence lists the binary operators, from lowest to highest precedence
+var precedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// parseBinary parses left-associative binary operators at the given level of precedence
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(precedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(precedence[level]...)
+		if !ok {
+			return left, nil
+		}
+		right, errR := p.parseBinary(level + 1)
+		if errR != nil {
+			return nil, errR
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if op, ok := p.accept("!", "-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{op: op, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.accept("."); ok {
+			t := p.next()
+			if t.kind != tokIdent {
+				return nil, fmt.Errorf("expected a name after '.' at position %d", t.pos)
+			}
+			n = indexNode{target: n, index: literalNode{value: t.text}}
+			continue
+		}
+		if _, ok := p.accept("["); ok {
+			idx, errI := p.parseTernary()
+			if errI != nil {
+				return nil, errI
+			}
+			if errI = p.expect("]"); errI != nil {
+				return nil, errI
+			}
+			n = indexNode{target: n, index: idx}
+			continue
+		}
+		return n, nil
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber, tokString:
+		return literalNode{value: t.value}, nil
+
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "nil", "null":
+			return literalNode{value: nil}, nil
+		}
+		if _, ok := p.accept("("); ok {
+			return p.parseCall(t)
+		}
+		return variableNode{name: t.text}, nil
+
+	case tokOperator:
+		if t.text == "(" {
+			n, err := p.parseTernary()
+			if err != nil {
+				return nil, err
+			}
+			return n, p.expect(")")
+		}
+
+	case tokEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+}
+
+// parseCall parses the arguments to a function call, the opening bracket having been consumed
+func (p *parser) parseCall(name token) (node, error) {
+	call := callNode{name: name.text, pos: name.pos}
+	if _, ok := p.accept(")"); ok {
+		return call, nil
+	}
+
+	for {
+		arg, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+
+		if _, ok := p.accept(","); !ok {
+			return call, p.expect(")")
+		}
+	}
+}
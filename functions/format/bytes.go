@@ -0,0 +1,91 @@
+package format
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// binary (IEC) and decimal (SI) unit prefixes for byte sizes
+var (
+	iecUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	siUnits  = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+)
+
+// HumanBytes formats a number of bytes using binary (IEC) units, eg "12.3 MiB"
+func HumanBytes(value any) (string, error) {
+	return humanBytes(value, 1024, iecUnits)
+}
+
+// HumanBytesSI formats a number of bytes using decimal (SI) units, eg "12.9 MB"
+func HumanBytesSI(value any) (string, error) {
+	return humanBytes(value, 1000, siUnits)
+}
+
+// ParseBytes converts a size with an IEC or SI unit (eg "12.3 MiB", "5MB" or "512") back to a number of bytes,
+// rounded to the nearest whole byte
+func ParseBytes(s string) (int64, error) {
+	str := strings.TrimSpace(s)
+	i := strings.IndexFunc(str, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+	})
+	num, unit := str, ""
+	if i >= 0 {
+		num, unit = str[:i], strings.TrimSpace(str[i:])
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parseBytes: can't parse %q as a size", s)
+	}
+	if unit == "" {
+		return wholeBytes(s, f)
+	}
+
+	for p, u := range iecUnits {
+		if strings.EqualFold(unit, u) {
+			return wholeBytes(s, f*math.Pow(1024, float64(p)))
+		}
+	}
+	for p, u := range siUnits {
+		if strings.EqualFold(unit, u) {
+			return wholeBytes(s, f*math.Pow(1000, float64(p)))
+		}
+	}
+	return 0, fmt.Errorf("parseBytes: unknown unit %q", unit)
+}
+
+// wholeBytes rounds the number of bytes, checking that it fits in an int64
+func wholeBytes(s string, f float64) (int64, error) {
+	r := math.Round(f)
+	if r >= math.MaxInt64 || r < math.MinInt64 {
+		return 0, fmt.Errorf("parseBytes: %q is too large", s)
+	}
+	return int64(r), nil
+}
+
+// humanBytes scales the value to the largest unit where it's at least 1 (once rounded)
+func humanBytes(value any, base float64, units []string) (string, error) {
+	f, err := helper.ToFloat(value)
+	if err != nil {
+		return "", err
+	}
+
+	p, places := 0, 0
+	// rounding can take the value up to the base, eg 1048575 bytes is 1024.0 KiB, which is shown as 1 MiB
+	for math.Abs(roundTo(f, places)) >= base && p < len(units)-1 {
+		f /= base
+		p++
+		places = 1
+	}
+	return trimFloat(f, places) + " " + units[p], nil
+}
+
+// roundTo rounds the value to the given number of decimal places
+func roundTo(f float64, places int) float64 {
+	m := math.Pow(10, float64(places))
+	return math.Round(f*m) / m
+}
@@ -0,0 +1,118 @@
+// Package format provides methods for presenting numbers in human-friendly ways in templates
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// Functions for formatting and parsing numbers
+func Functions() template.FuncMap {
+	return template.FuncMap{
+		"fixed":              Fixed,
+		"formatNumber":       FormatNumber,
+		"formatNumberLocale": FormatNumberLocale,
+		"parseNumber":        ParseNumber,
+		"parseNumberLocale":  ParseNumberLocale,
+		"humanBytes":         HumanBytes,
+		"humanBytesSI":       HumanBytesSI,
+		"parseBytes":         ParseBytes,
+		"percent":            Percent,
+		"parsePercent":       ParsePercent,
+		"ordinal":            Ordinal,
+		"parseOrdinal":       ParseOrdinal,
+		"toWordsNumber":      ToWordsNumber,
+		"parseWordsNumber":   ParseWordsNumber,
+	}
+}
+
+// Fixed formats the value with exactly the given number of decimal places
+func Fixed(places int, value any) (string, error) {
+	if places < 0 {
+		return "", fmt.Errorf("fixed: places cannot be negative")
+	}
+	f, err := helper.ToFloat(value)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(f, 'f', places, 64), nil
+}
+
+// Percent formats a fraction (eg 0.45) as a percentage (eg "45%") with the given number of decimal places
+func Percent(places int, value any) (string, error) {
+	f, err := helper.ToFloat(value)
+	if err != nil {
+		return "", err
+	}
+	if places < 0 {
+		return "", fmt.Errorf("percent: places cannot be negative")
+	}
+	return strconv.FormatFloat(f*100, 'f', places, 64) + "%", nil
+}
+
+// ParsePercent converts a percentage (eg "45%") back to a fraction (eg 0.45)
+func ParsePercent(s string) (float64, error) {
+	str := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsePercent: can't parse %q as a percentage", s)
+	}
+	return f / 100, nil
+}
+
+// Ordinal returns the number with its English ordinal suffix (eg "1st", "2nd", "3rd", "4th")
+func Ordinal(value any) (string, error) {
+	i, err := helper.ToInt(value)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d%s", i, ordinalSuffix(i)), nil
+}
+
+// ParseOrdinal converts a number with an ordinal suffix (eg "3rd") back to the number
+func ParseOrdinal(s string) (int64, error) {
+	str := strings.TrimSpace(s)
+	if len(str) < 3 {
+		return 0, fmt.Errorf("parseOrdinal: can't parse %q as an ordinal", s)
+	}
+	i, err := strconv.ParseInt(str[:len(str)-2], 10, 64)
+	if err != nil || !strings.EqualFold(str[len(str)-2:], ordinalSuffix(i)) {
+		return 0, fmt.Errorf("parseOrdinal: can't parse %q as an ordinal", s)
+	}
+	return i, nil
+}
+
+// ordinalSuffix returns the English ordinal suffix for a number
+func ordinalSuffix(i int64) string {
+	if i < 0 {
+		i = -i
+	}
+	if i%100 >= 11 && i%100 <= 13 {
+		return "th"
+	}
+	switch i % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// trimFloat formats f with up to the given number of decimal places, removing trailing zeros
+func trimFloat(f float64, places int) string {
+	s := strconv.FormatFloat(f, 'f', places, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
@@ -0,0 +1,491 @@
+package format
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFunctions provides unit test coverage for Functions
+func TestFunctions(t *testing.T) {
+	fn := Functions()
+	assert.Len(t, fn, 14, "weakly ensuring functions haven't been added/removed without updating tests")
+}
+
+// TestFixed provides unit test coverage for Fixed()
+func TestFixed(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "pads",
+			Template: `{{ fixed 2 3 }}`,
+			Want:     "3.00",
+		},
+		{
+			Name:     "rounds",
+			Template: `{{ fixed 1 .V }}`,
+			Args:     helper.TestArgs{"V": 2.25},
+			Want:     "2.2",
+		},
+		{
+			Name:     "no scientific notation",
+			Template: `{{ fixed 0 .V }}`,
+			Args:     helper.TestArgs{"V": 1e21},
+			Want:     "1000000000000000000000",
+		},
+		{
+			Name:     "negative places",
+			Template: `{{ fixed -1 3 }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "not a number",
+			Template: `{{ fixed 1 "x" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestFormatNumber provides unit test coverage for FormatNumber() and FormatNumberLocale()
+func TestFormatNumber(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "small",
+			Template: `{{ formatNumber 0 999 }}`,
+			Want:     "999",
+		},
+		{
+			Name:     "grouped",
+			Template: `{{ formatNumber 2 .V }}`,
+			Args:     helper.TestArgs{"V": 1234567.891},
+			Want:     "1,234,567.89",
+		},
+		{
+			Name:     "negative",
+			Template: `{{ formatNumber 0 .V }}`,
+			Args:     helper.TestArgs{"V": -1234567},
+			Want:     "-1,234,567",
+		},
+		{
+			Name:     "as many places as needed",
+			Template: `{{ formatNumber -1 .V }}`,
+			Args:     helper.TestArgs{"V": 1234.5},
+			Want:     "1,234.5",
+		},
+		{
+			Name:     "german",
+			Template: `{{ formatNumberLocale "de" 2 .V }}`,
+			Args:     helper.TestArgs{"V": 1234567.891},
+			Want:     "1.234.567,89",
+		},
+		{
+			Name:     "swiss german",
+			Template: `{{ formatNumberLocale "de_CH" 2 .V }}`,
+			Args:     helper.TestArgs{"V": 1234567.891},
+			Want:     "1’234’567.89",
+		},
+		{
+			Name:     "french",
+			Template: `{{ formatNumberLocale "fr-FR" 2 .V }}`,
+			Args:     helper.TestArgs{"V": 1234567.891},
+			Want:     "1 234 567,89",
+		},
+		{
+			Name:     "indian",
+			Template: `{{ formatNumberLocale "en-IN" 0 .V }}`,
+			Args:     helper.TestArgs{"V": 123456789},
+			Want:     "12,34,56,789",
+		},
+		{
+			Name:     "unknown locale",
+			Template: `{{ formatNumberLocale "xx" 0 1 }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "not a number",
+			Template: `{{ formatNumber 0 "x" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "infinity",
+			Template: `{{ formatNumber 2 .V }}`,
+			Args:     helper.TestArgs{"V": math.Inf(1)},
+			WantErr:  true,
+		},
+		{
+			Name:     "NaN",
+			Template: `{{ formatNumberLocale "de" 2 .V }}`,
+			Args:     helper.TestArgs{"V": math.NaN()},
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestLocaleFormatNonFinite ensures that infinities and NaN aren't grouped
+func TestLocaleFormatNonFinite(t *testing.T) {
+	l, err := LookupLocale("en")
+	require.NoError(t, err)
+	assert.Equal(t, "+Inf", l.Format(math.Inf(1), 2))
+	assert.Equal(t, "-Inf", l.Format(math.Inf(-1), 0))
+	assert.Equal(t, "NaN", l.Format(math.NaN(), 2))
+}
+
+// TestParseNumber provides unit test coverage for ParseNumber() and ParseNumberLocale()
+func TestParseNumber(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "english",
+			Template: `{{ parseNumber "1,234,567.89" }}`,
+			Want:     "1.23456789e+06",
+		},
+		{
+			Name:     "round trip",
+			Template: `{{ formatNumber 2 .V | parseNumber | formatNumber 2 }}`,
+			Args:     helper.TestArgs{"V": -9876543.21},
+			Want:     "-9,876,543.21",
+		},
+		{
+			Name:     "german",
+			Template: `{{ parseNumberLocale "de" "-1.234,5" }}`,
+			Want:     "-1234.5",
+		},
+		{
+			Name:     "french with plain spaces",
+			Template: `{{ parseNumberLocale "fr" "1 234,5" }}`,
+			Want:     "1234.5",
+		},
+		{
+			Name:     "unknown locale",
+			Template: `{{ parseNumberLocale "xx" "1" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "not a number",
+			Template: `{{ parseNumber "one" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestHumanBytes provides unit test coverage for HumanBytes() and HumanBytesSI()
+func TestHumanBytes(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "bytes",
+			Template: `{{ humanBytes 512 }}`,
+			Want:     "512 B",
+		},
+		{
+			Name:     "exact",
+			Template: `{{ humanBytes 1024 }}`,
+			Want:     "1 KiB",
+		},
+		{
+			Name:     "largest before the next unit",
+			Template: `{{ humanBytes 1023 }}`,
+			Want:     "1023 B",
+		},
+		{
+			Name:     "rounded up to the next unit",
+			Template: `{{ humanBytes 1048575 }}`,
+			Want:     "1 MiB",
+		},
+		{
+			Name:     "SI rounded up to the next unit",
+			Template: `{{ humanBytesSI 999999 }}`,
+			Want:     "1 MB",
+		},
+		{
+			Name:     "rounded fraction of a byte",
+			Template: `{{ humanBytes .V }}`,
+			Args:     helper.TestArgs{"V": 1023.7},
+			Want:     "1 KiB",
+		},
+		{
+			Name:     "mebibytes",
+			Template: `{{ humanBytes 12897484 }}`,
+			Want:     "12.3 MiB",
+		},
+		{
+			Name:     "SI",
+			Template: `{{ humanBytesSI 12897484 }}`,
+			Want:     "12.9 MB",
+		},
+		{
+			Name:     "largest unit",
+			Template: `{{ humanBytesSI .V }}`,
+			Args:     helper.TestArgs{"V": 5e21},
+			Want:     "5000 EB",
+		},
+		{
+			Name:     "not a number",
+			Template: `{{ humanBytes "x" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestParseBytes provides unit test coverage for ParseBytes()
+func TestParseBytes(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "no unit",
+			Template: `{{ parseBytes "512" }}`,
+			Want:     "512",
+		},
+		{
+			Name:     "IEC",
+			Template: `{{ parseBytes "1.5 KiB" }}`,
+			Want:     "1536",
+		},
+		{
+			Name:     "SI without space",
+			Template: `{{ parseBytes "5MB" }}`,
+			Want:     "5000000",
+		},
+		{
+			Name:     "fraction rounded to whole bytes",
+			Template: `{{ parseBytes "12.3 MiB" }}`,
+			Want:     "12897485",
+		},
+		{
+			Name:     "too large",
+			Template: `{{ parseBytes "8 EiB" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "round trip",
+			Template: `{{ humanBytes 3221225472 | parseBytes | humanBytes }}`,
+			Want:     "3 GiB",
+		},
+		{
+			Name:     "unknown unit",
+			Template: `{{ parseBytes "5 XB" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "no number",
+			Template: `{{ parseBytes "MB" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestPercent provides unit test coverage for Percent() and ParsePercent()
+func TestPercent(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "whole",
+			Template: `{{ percent 0 .V }}`,
+			Args:     helper.TestArgs{"V": 0.45},
+			Want:     "45%",
+		},
+		{
+			Name:     "places",
+			Template: `{{ percent 1 .V }}`,
+			Args:     helper.TestArgs{"V": 0.12345},
+			Want:     "12.3%",
+		},
+		{
+			Name:     "negative places",
+			Template: `{{ percent -1 .V }}`,
+			Args:     helper.TestArgs{"V": 0.12345},
+			WantErr:  true,
+		},
+		{
+			Name:     "not a number",
+			Template: `{{ percent 1 "x" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "parse",
+			Template: `{{ parsePercent "45%" }}`,
+			Want:     "0.45",
+		},
+		{
+			Name:     "parse with space",
+			Template: `{{ parsePercent " 12.5 %" }}`,
+			Want:     "0.125",
+		},
+		{
+			Name:     "parse bad",
+			Template: `{{ parsePercent "lots%" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestOrdinal provides unit test coverage for Ordinal() and ParseOrdinal()
+func TestOrdinal(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "several",
+			Template: `{{ range .V }}{{ ordinal . }} {{ end }}`,
+			Args:     helper.TestArgs{"V": []int{0, 1, 2, 3, 4, 11, 12, 13, 21, 22, 23, 101, 111, -1}},
+			Want:     "0th 1st 2nd 3rd 4th 11th 12th 13th 21st 22nd 23rd 101st 111th -1st ",
+		},
+		{
+			Name:     "not an integer",
+			Template: `{{ ordinal 1.5 }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "parse",
+			Template: `{{ parseOrdinal "23rd" }}`,
+			Want:     "23",
+		},
+		{
+			Name:     "parse wrong suffix",
+			Template: `{{ parseOrdinal "23th" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "parse too short",
+			Template: `{{ parseOrdinal "st" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestToWordsNumber provides unit test coverage for ToWordsNumber()
+func TestToWordsNumber(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "zero",
+			Template: `{{ toWordsNumber 0 }}`,
+			Want:     "zero",
+		},
+		{
+			Name:     "forty-two",
+			Template: `{{ toWordsNumber 42 }}`,
+			Want:     "forty-two",
+		},
+		{
+			Name:     "teens and tens",
+			Template: `{{ toWordsNumber 13 }}, {{ toWordsNumber 80 }}`,
+			Want:     "thirteen, eighty",
+		},
+		{
+			Name:     "large",
+			Template: `{{ toWordsNumber 1002003 }}`,
+			Want:     "one million two thousand three",
+		},
+		{
+			Name:     "hundreds",
+			Template: `{{ toWordsNumber -315 }}`,
+			Want:     "minus three hundred fifteen",
+		},
+		{
+			Name:     "min int",
+			Template: `{{ toWordsNumber .V }}`,
+			Args:     helper.TestArgs{"V": int64(-9223372036854775808)},
+			Want: "minus nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion " +
+				"thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight",
+		},
+		{
+			Name:     "not an integer",
+			Template: `{{ toWordsNumber 1.5 }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestParseWordsNumber provides unit test coverage for ParseWordsNumber()
+func TestParseWordsNumber(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "forty-two",
+			Template: `{{ parseWordsNumber "forty-two" }}`,
+			Want:     "42",
+		},
+		{
+			Name:     "british style",
+			Template: `{{ parseWordsNumber "One hundred and one thousand, two hundred and five" }}`,
+			Want:     "101205",
+		},
+		{
+			Name:     "minus zero",
+			Template: `{{ parseWordsNumber "minus zero" }}`,
+			Want:     "0",
+		},
+		{
+			Name:     "round trip",
+			Template: `{{ toWordsNumber .V | parseWordsNumber }}`,
+			Args:     helper.TestArgs{"V": int64(-9223372036854775808)},
+			Want:     "-9223372036854775808",
+		},
+		{
+			Name:     "too big",
+			Template: `{{ parseWordsNumber "ten quintillion" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "too small",
+			Template: `{{ parseWordsNumber "minus ten quintillion" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "way too big",
+			Template: `{{ parseWordsNumber "one hundred quintillion" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "too big when added",
+			Template: `{{ parseWordsNumber "ten quintillion ten quintillion" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "too many hundreds",
+			Template: `{{ parseWordsNumber "one hundred hundred hundred hundred hundred hundred hundred hundred hundred hundred" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "unknown word",
+			Template: `{{ parseWordsNumber "forty-lots" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "empty",
+			Template: `{{ parseWordsNumber "" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -0,0 +1,155 @@
+package format
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// DefaultLocale is the locale used by FormatNumber and ParseNumber
+const DefaultLocale = "en"
+
+// Locale describes how numbers are written in a particular region
+type Locale struct {
+	Group   string // separator between groups of digits
+	Decimal string // separator between the whole and fractional parts
+	Indian  bool   // group by 3 digits then by 2 (eg 12,34,567)
+}
+
+// Locales that are known to the number formatting methods, keyed by language or language-region code
+var Locales = map[string]Locale{
+	"en":    {Group: ",", Decimal: "."},
+	"en-in": {Group: ",", Decimal: ".", Indian: true},
+	"hi":    {Group: ",", Decimal: ".", Indian: true},
+	"ja":    {Group: ",", Decimal: "."},
+	"ko":    {Group: ",", Decimal: "."},
+	"zh":    {Group: ",", Decimal: "."},
+	"de":    {Group: ".", Decimal: ","},
+	"de-ch": {Group: "\u2019", Decimal: "."},
+	"es":    {Group: ".", Decimal: ","},
+	"it":    {Group: ".", Decimal: ","},
+	"nl":    {Group: ".", Decimal: ","},
+	"pt":    {Group: ".", Decimal: ","},
+	"da":    {Group: ".", Decimal: ","},
+	"fr":    {Group: "\u202f", Decimal: ","},
+	"fr-ch": {Group: "\u202f", Decimal: "."},
+	"ru":    {Group: "\u00a0", Decimal: ","},
+	"pl":    {Group: "\u00a0", Decimal: ","},
+	"sv":    {Group: "\u00a0", Decimal: ","},
+	"nb":    {Group: "\u00a0", Decimal: ","},
+	"fi":    {Group: "\u00a0", Decimal: ","},
+	"cs":    {Group: "\u00a0", Decimal: ","},
+}
+
+// LookupLocale finds the locale for the given code (eg "de", "de-CH" or "de_CH"),
+// falling back to the language if the region isn't known
+func LookupLocale(code string) (Locale, error) {
+	c := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "_", "-"))
+	if l, ok := Locales[c]; ok {
+		return l, nil
+	}
+	if lang, _, found := strings.Cut(c, "-"); found {
+		if l, ok := Locales[lang]; ok {
+			return l, nil
+		}
+	}
+	return Locale{}, fmt.Errorf("unknown locale %q", code)
+}
+
+// FormatNumber formats the value with grouped thousands and the given number of decimal places (eg 1,234,567.89).
+// A negative number of places uses as many as needed
+func FormatNumber(places int, value any) (string, error) {
+	return FormatNumberLocale(DefaultLocale, places, value)
+}
+
+// FormatNumberLocale formats the value using the separators of the given locale (eg 1.234.567,89 for "de")
+func FormatNumberLocale(locale string, places int, value any) (string, error) {
+	l, err := LookupLocale(locale)
+	if err != nil {
+		return "", err
+	}
+	f, err := helper.ToFloat(value)
+	if err != nil {
+		return "", err
+	}
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return "", fmt.Errorf("can't format %v as a number", f)
+	}
+	return l.Format(f, places), nil
+}
+
+// ParseNumber converts a number formatted by FormatNumber back to a float
+func ParseNumber(s string) (float64, error) {
+	return ParseNumberLocale(DefaultLocale, s)
+}
+
+// ParseNumberLocale converts a number formatted by FormatNumberLocale back to a float
+func ParseNumberLocale(locale string, s string) (float64, error) {
+	l, err := LookupLocale(locale)
+	if err != nil {
+		return 0, err
+	}
+	return l.Parse(s)
+}
+
+// Format writes the number using the separators of this locale.
+// Infinities and NaN are returned as they are formatted by strconv, without separators
+func (l Locale) Format(f float64, places int) string {
+	s := strconv.FormatFloat(f, 'f', places, 64)
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return s
+	}
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	whole, frac, hasFrac := strings.Cut(s, ".")
+
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	b.WriteString(l.group(whole))
+	if hasFrac {
+		b.WriteString(l.Decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// Parse reads a number written using the separators of this locale
+func (l Locale) Parse(s string) (float64, error) {
+	str := strings.TrimSpace(s)
+	str = strings.ReplaceAll(str, l.Group, "")
+	str = strings.ReplaceAll(str, " ", "")
+	str = strings.ReplaceAll(str, l.Decimal, ".")
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse %q as a number", s)
+	}
+	return f, nil
+}
+
+// group inserts the group separator into a string of digits
+func (l Locale) group(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	head, tail := digits[:len(digits)-3], digits[len(digits)-3:]
+	size := 3
+	if l.Indian {
+		size = 2
+	}
+
+	var parts []string
+	for len(head) > size {
+		parts = append([]string{head[len(head)-size:]}, parts...)
+		head = head[:len(head)-size]
+	}
+	parts = append([]string{head}, parts...)
+	parts = append(parts, tail)
+	return strings.Join(parts, l.Group)
+}
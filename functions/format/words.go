@@ -0,0 +1,176 @@
+package format
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+var (
+	smallNumbers = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+	tens = []string{
+		"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety",
+	}
+	scales = []string{
+		"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion",
+	}
+)
+
+// ToWordsNumber writes a whole number out in English words, eg 42 becomes "forty-two"
+func ToWordsNumber(value any) (string, error) {
+	i, err := helper.ToInt(value)
+	if err != nil {
+		return "", err
+	}
+
+	if i == 0 {
+		return smallNumbers[0], nil
+	}
+
+	var words []string
+	u := uint64(i)
+	if i < 0 {
+		words = append(words, "minus")
+		u = uint64(-(i + 1)) + 1 // avoid overflow for math.MinInt64
+	}
+
+	var groups []string
+	for scale := 0; u > 0; scale++ {
+		g := u % 1000
+		u /= 1000
+		if g == 0 {
+			continue
+		}
+		w := hundredsToWords(int(g))
+		if scales[scale] != "" {
+			w += " " + scales[scale]
+		}
+		groups = append([]string{w}, groups...)
+	}
+
+	return strings.Join(append(words, groups...), " "), nil
+}
+
+// ParseWordsNumber converts a number written in English words (as produced by ToWordsNumber) back to a number
+func ParseWordsNumber(s string) (int64, error) {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ' ' || r == '-' || r == ','
+	})
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("parseWordsNumber: no number in %q", s)
+	}
+
+	neg := false
+	if fields[0] == "minus" || fields[0] == "negative" {
+		neg = true
+		fields = fields[1:]
+	}
+
+	overflow := fmt.Errorf("parseWordsNumber: %q overflows int64", s)
+	var total, group uint64
+	var ok bool
+	for _, f := range fields {
+		if f == "and" {
+			continue
+		}
+		if n, isWord := wordValue(f); isWord {
+			if group, ok = addUint64(group, n); !ok {
+				return 0, overflow
+			}
+			continue
+		}
+		if f == "hundred" {
+			if group, ok = mulUint64(group, 100); !ok {
+				return 0, overflow
+			}
+			continue
+		}
+		scale := indexOf(scales, f)
+		if scale < 1 {
+			return 0, fmt.Errorf("parseWordsNumber: unknown word %q in %q", f, s)
+		}
+		mult := uint64(1)
+		for i := 0; i < scale; i++ {
+			mult *= 1000
+		}
+		if group, ok = mulUint64(group, mult); !ok {
+			return 0, overflow
+		}
+		if total, ok = addUint64(total, group); !ok {
+			return 0, overflow
+		}
+		group = 0
+	}
+	if total, ok = addUint64(total, group); !ok {
+		return 0, overflow
+	}
+
+	if neg && total > 0 {
+		if total > math.MaxInt64+1 {
+			return 0, overflow
+		}
+		return -int64(total-1) - 1, nil
+	}
+	if total > math.MaxInt64 {
+		return 0, overflow
+	}
+	return int64(total), nil
+}
+
+// addUint64 returns a+b, and false if it overflows
+func addUint64(a, b uint64) (uint64, bool) {
+	sum, carry := bits.Add64(a, b, 0)
+	return sum, carry == 0
+}
+
+// mulUint64 returns a*b, and false if it overflows
+func mulUint64(a, b uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(a, b)
+	return lo, hi == 0
+}
+
+// hundredsToWords writes a number from 1 to 999 in words
+func hundredsToWords(n int) string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, smallNumbers[n/100]+" hundred")
+		n %= 100
+	}
+	switch {
+	case n == 0:
+	case n < 20:
+		parts = append(parts, smallNumbers[n])
+	case n%10 == 0:
+		parts = append(parts, tens[n/10])
+	default:
+		parts = append(parts, tens[n/10]+"-"+smallNumbers[n%10])
+	}
+	return strings.Join(parts, " ")
+}
+
+// wordValue returns the value of a word for a number less than 100
+func wordValue(w string) (uint64, bool) {
+	if i := indexOf(smallNumbers, w); i >= 0 {
+		return uint64(i), true
+	}
+	if i := indexOf(tens, w); i >= 2 {
+		return uint64(i * 10), true
+	}
+	return 0, false
+}
+
+// indexOf returns the position of s in list, or -1 if it's not present
+func indexOf(list []string, s string) int {
+	for i, l := range list {
+		if l == s {
+			return i
+		}
+	}
+	return -1
+}
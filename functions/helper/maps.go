@@ -1,5 +1,10 @@
 package helper
 
+import (
+	"fmt"
+	"reflect"
+)
+
 // Combine a slice of maps into a single map, with key collisions, later overwrites earlier
 func Combine[M ~map[K]V, K comparable, V any](maps ...M) M {
 	res := make(M)
@@ -10,3 +15,27 @@ func Combine[M ~map[K]V, K comparable, V any](maps ...M) M {
 	}
 	return res
 }
+
+// AsMap returns the given map as the generic map[string]any type, converting keys to strings as required,
+// or an error message if it's not a map
+func AsMap(m any) (map[string]any, error) {
+	if m == nil {
+		return nil, fmt.Errorf("map is nil")
+	}
+
+	if d, ok := m.(map[string]any); ok {
+		return d, nil
+	}
+
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map {
+		return nil, fmt.Errorf("type %s is not a map", v.Kind())
+	}
+
+	res := make(map[string]any, v.Len())
+	i := v.MapRange()
+	for i.Next() {
+		res[fmt.Sprintf("%v", i.Key().Interface())] = i.Value().Interface()
+	}
+	return res, nil
+}
@@ -1,7 +1,10 @@
 package helper
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"reflect"
 	"strconv"
 )
 
@@ -24,15 +27,141 @@ func ToFloat(a any) (float64, error) {
 		f = float64(v)
 	case int32:
 		f = float64(v)
+	case int64:
+		f = float64(v)
+	case uint:
+		f = float64(v)
+	case uint8:
+		f = float64(v)
+	case uint16:
+		f = float64(v)
+	case uint32:
+		f = float64(v)
+	case uint64:
+		f = float64(v)
 	case float32:
 		f = float64(v)
 	case float64:
 		f = v
+	case json.Number:
+		f, err = v.Float64()
 	case string:
 		f, err = strconv.ParseFloat(v, 64)
 	default:
-		err = fmt.Errorf("toFloat: can't convert from %T to float", a)
+		rv, ok := numericValue(a)
+		if !ok {
+			return 0, fmt.Errorf("toFloat: can't convert from %T to float", a)
+		}
+		return ToFloat(rv.Interface())
 	}
 
 	return f, err
 }
+
+// ToInt converts the supplied argument to a 64-bit integer, if it can be done without losing precision
+func ToInt(a any) (int64, error) {
+	var i int64
+	var err error
+
+	switch v := a.(type) {
+	case bool:
+		i = 0
+		if v {
+			i = 1
+		}
+	case int:
+		i = int64(v)
+	case int8:
+		i = int64(v)
+	case int16:
+		i = int64(v)
+	case int32:
+		i = int64(v)
+	case int64:
+		i = v
+	case uint:
+		i, err = uintToInt(uint64(v))
+	case uint8:
+		i = int64(v)
+	case uint16:
+		i = int64(v)
+	case uint32:
+		i = int64(v)
+	case uint64:
+		i, err = uintToInt(v)
+	case float32:
+		i, err = floatToInt(float64(v))
+	case float64:
+		i, err = floatToInt(v)
+	case json.Number:
+		i, err = stringToInt(string(v))
+	case string:
+		i, err = stringToInt(v)
+	default:
+		rv, ok := numericValue(a)
+		if !ok {
+			return 0, fmt.Errorf("toInt: can't convert from %T to int", a)
+		}
+		return ToInt(rv.Interface())
+	}
+
+	return i, err
+}
+
+// uintToInt converts an unsigned integer to a signed one, checking for overflow
+func uintToInt(u uint64) (int64, error) {
+	if u > math.MaxInt64 {
+		return 0, fmt.Errorf("toInt: %d overflows int64", u)
+	}
+	return int64(u), nil
+}
+
+// floatToInt converts a float to an integer, provided it has no fractional part and is within range
+func floatToInt(f float64) (int64, error) {
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("toInt: %v is not an integer", f)
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("toInt: %v overflows int64", f)
+	}
+	return int64(f), nil
+}
+
+// stringToInt parses a string as an integer, also accepting floating point notation for integral values (eg 1e3)
+func stringToInt(s string) (int64, error) {
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		return i, nil
+	}
+	if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+		return 0, fmt.Errorf("toInt: %s overflows int64", s)
+	}
+
+	f, errF := strconv.ParseFloat(s, 64)
+	if errF != nil {
+		return 0, fmt.Errorf("toInt: can't convert %q to int", s)
+	}
+	return floatToInt(f)
+}
+
+// numericValue converts named types with an underlying numeric kind back to their base type
+func numericValue(a any) (reflect.Value, bool) {
+	if a == nil {
+		return reflect.Value{}, false
+	}
+
+	v := reflect.ValueOf(a)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.ValueOf(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.ValueOf(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return reflect.ValueOf(v.Float()), true
+	case reflect.Bool:
+		return reflect.ValueOf(v.Bool()), true
+	case reflect.String:
+		return reflect.ValueOf(v.String()), true
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,72 @@
+package helper
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Options are settings given to a function as a dictionary, eg {{ toXMLWith (dict "root" "config") . }}
+type Options map[string]any
+
+// NewOptions converts a dictionary to Options, checking that only the known options are used
+func NewOptions(o any, known ...string) (Options, error) {
+	if o == nil {
+		return Options{}, nil
+	}
+	m, err := AsMap(o)
+	if err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+
+	allowed := map[string]bool{}
+	for _, k := range known {
+		allowed[k] = true
+	}
+	for k := range m {
+		if !allowed[k] {
+			names := append([]string(nil), known...) // sorted without changing the caller's list
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown option %q, expected one of %v", k, names)
+		}
+	}
+	return m, nil
+}
+
+// String returns the named string option, or the default if it isn't set
+func (o Options) String(name, def string) (string, error) {
+	v, ok := o[name]
+	if !ok {
+		return def, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("option %q should be a string, not %T", name, v)
+	}
+	return s, nil
+}
+
+// Bool returns the named true/false option, or the default if it isn't set
+func (o Options) Bool(name string, def bool) (bool, error) {
+	v, ok := o[name]
+	if !ok {
+		return def, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("option %q should be true or false, not %T", name, v)
+	}
+	return b, nil
+}
+
+// Int returns the named whole number option, or the default if it isn't set
+func (o Options) Int(name string, def int) (int, error) {
+	v, ok := o[name]
+	if !ok {
+		return def, nil
+	}
+	i, err := ToInt(v)
+	if err != nil {
+		return 0, fmt.Errorf("option %q should be a whole number: %w", name, err)
+	}
+	return int(i), nil
+}
@@ -0,0 +1,149 @@
+package helper
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PathStep is a single step in a path into nested data, either a key (for maps and structs) or an index (for lists)
+type PathStep struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// ParsePath splits a path such as `a.b[0].c` or `["a.b"][-1]` into a set of steps.
+// A leading `$` or `.` is ignored, so `$.a`, `.a` and `a` are all equivalent
+func ParsePath(path string) ([]PathStep, error) {
+	var steps []PathStep
+
+	p := strings.TrimPrefix(path, "$")
+	if p == "" || p == "." {
+		return steps, nil // refers to the whole document
+	}
+	if p[0] != '.' && p[0] != '[' {
+		p = "." + p
+	}
+
+	at := 0
+	for at < len(p) {
+		switch p[at] {
+		case '.':
+			at++
+			end := at
+			for end < len(p) && p[end] != '.' && p[end] != '[' {
+				end++
+			}
+			if end == at {
+				return nil, fmt.Errorf("invalid path %q: empty key", path)
+			}
+			steps = append(steps, PathStep{Key: p[at:end]})
+			at = end
+		case '[':
+			end := strings.IndexByte(p[at:], ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid path %q: unterminated '['", path)
+			}
+			inner := strings.TrimSpace(p[at+1 : at+end])
+			step, err := parseBracket(inner)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %w", path, err)
+			}
+			steps = append(steps, step)
+			at += end + 1
+		default:
+			return nil, fmt.Errorf("invalid path %q: unexpected %q", path, p[at])
+		}
+	}
+
+	return steps, nil
+}
+
+// parseBracket interprets the content of a [...] path step
+func parseBracket(inner string) (PathStep, error) {
+	if len(inner) >= 2 && (inner[0] == '"' || inner[0] == '\'') && inner[len(inner)-1] == inner[0] {
+		return PathStep{Key: inner[1 : len(inner)-1]}, nil
+	}
+	i, err := strconv.Atoi(inner)
+	if err != nil {
+		return PathStep{}, fmt.Errorf("expected an index or quoted key, got %q", inner)
+	}
+	return PathStep{Index: i, IsIndex: true}, nil
+}
+
+// LookupPath follows the given path into the data, returning the value found and if it was present
+func LookupPath(path string, data any) (any, bool, error) {
+	steps, err := ParsePath(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	v, ok := Follow(steps, data)
+	return v, ok, nil
+}
+
+// Follow applies the set of steps to the data, returning the value found and if it was present
+func Follow(steps []PathStep, data any) (any, bool) {
+	cur := data
+	for _, s := range steps {
+		var ok bool
+		if s.IsIndex {
+			cur, ok = Index(cur, s.Index)
+		} else {
+			cur, ok = Field(cur, s.Key)
+		}
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// Index returns the item at position i of a list, counting from the end if i is negative
+func Index(list any, i int) (any, bool) {
+	a, l, err := ListInfo(list)
+	if err != nil {
+		return nil, false
+	}
+	if i < 0 {
+		i += l
+	}
+	if i < 0 || i >= l {
+		return nil, false
+	}
+	return a.Index(i).Interface(), true
+}
+
+// Field returns the value of the named key of a map, or exported field of a struct
+func Field(data any, key string) (any, bool) {
+	if data == nil {
+		return nil, false
+	}
+
+	if m, ok := data.(map[string]any); ok {
+		v, found := m[key]
+		return v, found
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(data))
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		r := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		if !r.IsValid() {
+			return nil, false
+		}
+		return r.Interface(), true
+	case reflect.Struct:
+		f, found := v.Type().FieldByName(key)
+		if !found || !f.IsExported() {
+			return nil, false
+		}
+		return v.FieldByIndex(f.Index).Interface(), true
+	}
+	return nil, false
+}
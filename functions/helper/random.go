@@ -0,0 +1,40 @@
+package helper
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Random is the source of numbers for the (non-cryptographic) random functions.
+// It is defined as a variable so that it can be overridden as required (e.g. unit testing)
+var Random = NewRandom(time.Now().UnixNano())
+
+// NewRandom returns a source of random numbers, safe for concurrent use, that produces the same sequence of numbers
+// each time it's given the same seed
+func NewRandom(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewSource(seed)})
+}
+
+// SeedRandom resets Random so that it produces the same sequence of numbers each time it's given the same seed
+func SeedRandom(seed int64) {
+	Random.Seed(seed)
+}
+
+// lockedSource makes a rand.Source safe for concurrent use
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
@@ -0,0 +1,198 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// errEmptyList is returned by aggregates that have no meaningful value for an empty list
+var errEmptyList = errors.New("list is empty")
+
+// Sum returns the total of all the values in the list
+func Sum(list any) (float64, error) {
+	f, err := toFloatList(list)
+	if err != nil {
+		return 0, err
+	}
+	return sum(f), nil
+}
+
+// SumBy returns the total of the values found at the given path (eg "cost" or "price.amount") in each item of the list
+func SumBy(path string, list any) (float64, error) {
+	f, err := pluckFloatList(path, list)
+	if err != nil {
+		return 0, err
+	}
+	return sum(f), nil
+}
+
+// Average returns the mean of the values in the list
+func Average(list any) (float64, error) {
+	f, err := toFloatList(list)
+	if err != nil {
+		return 0, err
+	}
+	return average(f)
+}
+
+// AverageBy returns the mean of the values found at the given path in each item of the list
+func AverageBy(path string, list any) (float64, error) {
+	f, err := pluckFloatList(path, list)
+	if err != nil {
+		return 0, err
+	}
+	return average(f)
+}
+
+// Median returns the middle value of the list, or the mean of the two middle values if it has an even length
+func Median(list any) (float64, error) {
+	f, err := toFloatList(list)
+	if err != nil {
+		return 0, err
+	}
+	return percentile(50, f)
+}
+
+// MedianBy returns the median of the values found at the given path in each item of the list
+func MedianBy(path string, list any) (float64, error) {
+	f, err := pluckFloatList(path, list)
+	if err != nil {
+		return 0, err
+	}
+	return percentile(50, f)
+}
+
+// Percentile returns the p-th percentile (0-100) of the values in the list,
+// interpolating linearly between the closest ranks
+func Percentile(p any, list any) (float64, error) {
+	pf, err := helper.ToFloat(p)
+	if err != nil {
+		return 0, err
+	}
+	f, err := toFloatList(list)
+	if err != nil {
+		return 0, err
+	}
+	return percentile(pf, f)
+}
+
+// PercentileBy returns the p-th percentile (0-100) of the values found at the given path in each item of the list
+func PercentileBy(p any, path string, list any) (float64, error) {
+	pf, err := helper.ToFloat(p)
+	if err != nil {
+		return 0, err
+	}
+	f, err := pluckFloatList(path, list)
+	if err != nil {
+		return 0, err
+	}
+	return percentile(pf, f)
+}
+
+// StandardDeviation returns the (population) standard deviation of the values in the list
+func StandardDeviation(list any) (float64, error) {
+	f, err := toFloatList(list)
+	if err != nil {
+		return 0, err
+	}
+	return stddev(f)
+}
+
+// StandardDeviationBy returns the (population) standard deviation of the values found at the given path
+// in each item of the list
+func StandardDeviationBy(path string, list any) (float64, error) {
+	f, err := pluckFloatList(path, list)
+	if err != nil {
+		return 0, err
+	}
+	return stddev(f)
+}
+
+// toFloatList converts each item of a list to a float
+func toFloatList(list any) ([]float64, error) {
+	a, l, err := helper.ListInfo(list)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]float64, l)
+	for c := 0; c < l; c++ {
+		res[c], err = helper.ToFloat(a.Index(c).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", c, err)
+		}
+	}
+	return res, nil
+}
+
+// pluckFloatList converts the value at the given path in each item of a list to a float
+func pluckFloatList(path string, list any) ([]float64, error) {
+	steps, err := helper.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	a, l, err := helper.ListInfo(list)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]float64, l)
+	for c := 0; c < l; c++ {
+		v, ok := helper.Follow(steps, a.Index(c).Interface())
+		if !ok {
+			return nil, fmt.Errorf("item %d has no value at %q", c, path)
+		}
+		res[c], err = helper.ToFloat(v)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", c, err)
+		}
+	}
+	return res, nil
+}
+
+func sum(f []float64) float64 {
+	return helper.Reduce(f, 0, func(a, b float64) float64 {
+		return a + b
+	})
+}
+
+func average(f []float64) (float64, error) {
+	if len(f) == 0 {
+		return 0, errEmptyList
+	}
+	return sum(f) / float64(len(f)), nil
+}
+
+func percentile(p float64, f []float64) (float64, error) {
+	if len(f) == 0 {
+		return 0, errEmptyList
+	}
+	if math.IsNaN(p) || p < 0 || p > 100 {
+		return 0, fmt.Errorf("percentile %v is not between 0 and 100", p)
+	}
+
+	s := make([]float64, len(f))
+	copy(s, f)
+	sort.Float64s(s)
+
+	rank := p / 100 * float64(len(s)-1)
+	lower := math.Floor(rank)
+	upper := math.Ceil(rank)
+	return s[int(lower)] + (s[int(upper)]-s[int(lower)])*(rank-lower), nil
+}
+
+func stddev(f []float64) (float64, error) {
+	mean, err := average(f)
+	if err != nil {
+		return 0, err
+	}
+	variance := helper.Reduce(f, 0, func(a, b float64) float64 {
+		return a + (b-mean)*(b-mean)
+	}) / float64(len(f))
+	return math.Sqrt(variance), nil
+}
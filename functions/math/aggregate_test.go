@@ -0,0 +1,358 @@
+package math
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/require"
+)
+
+// items returns a list of objects as decoded from JSON
+func items(t *testing.T) []any {
+	t.Helper()
+	var i []any
+	err := json.Unmarshal([]byte(`[
+		{"name": "a", "cost": 10, "size": {"w": 1}},
+		{"name": "b", "cost": 2.5, "size": {"w": 2}},
+		{"name": "c", "cost": 7.5, "size": {"w": 3}},
+		{"name": "d", "cost": 4, "size": {"w": 4}}
+	]`), &i)
+	require.NoError(t, err)
+	return i
+}
+
+// TestSum provides unit test coverage for Sum and SumBy.
+func TestSum(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "empty",
+			Template: "{{- sum .L -}}",
+			Args: helper.TestArgs{
+				"L": []any{},
+			},
+			Want: "0",
+		},
+		{
+			Name:     "numbers",
+			Template: "{{- sum .L -}}",
+			Args: helper.TestArgs{
+				"L": []any{1.0, 2.5, "3"},
+			},
+			Want: "6.5",
+		},
+		{
+			Name:     "not a list",
+			Template: "{{- sum 5 -}}",
+			WantErr:  true,
+		},
+		{
+			Name:     "not numbers",
+			Template: "{{- sum .L -}}",
+			Args: helper.TestArgs{
+				"L": []any{1.0, "x"},
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "by key",
+			Template: `{{- sumBy "cost" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			Want: "24",
+		},
+		{
+			Name:     "by nested key",
+			Template: `{{- sumBy "size.w" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			Want: "10",
+		},
+		{
+			Name:     "by missing key",
+			Template: `{{- sumBy "weight" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "by non-numeric key",
+			Template: `{{- sumBy "name" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "by bad path",
+			Template: `{{- sumBy "size..w" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "by not a list",
+			Template: `{{- sumBy "cost" 5 -}}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestAverage provides unit test coverage for Average and AverageBy.
+func TestAverage(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "numbers",
+			Template: "{{- avg .L -}}",
+			Args: helper.TestArgs{
+				"L": []int{1, 2, 3, 4},
+			},
+			Want: "2.5",
+		},
+		{
+			Name:     "empty",
+			Template: "{{- avg .L -}}",
+			Args: helper.TestArgs{
+				"L": []int{},
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "not a list",
+			Template: "{{- avg 5 -}}",
+			WantErr:  true,
+		},
+		{
+			Name:     "by key",
+			Template: `{{- avgBy "cost" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			Want: "6",
+		},
+		{
+			Name:     "by missing key",
+			Template: `{{- avgBy "weight" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestMedian provides unit test coverage for Median and MedianBy.
+func TestMedian(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "odd",
+			Template: "{{- median .L -}}",
+			Args: helper.TestArgs{
+				"L": []int{5, 1, 3},
+			},
+			Want: "3",
+		},
+		{
+			Name:     "even",
+			Template: "{{- median .L -}}",
+			Args: helper.TestArgs{
+				"L": []int{5, 1, 3, 4},
+			},
+			Want: "3.5",
+		},
+		{
+			Name:     "empty",
+			Template: "{{- median .L -}}",
+			Args: helper.TestArgs{
+				"L": []int{},
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "not a list",
+			Template: "{{- median 5 -}}",
+			WantErr:  true,
+		},
+		{
+			Name:     "by key",
+			Template: `{{- medianBy "cost" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			Want: "5.75",
+		},
+		{
+			Name:     "by missing key",
+			Template: `{{- medianBy "weight" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestPercentile provides unit test coverage for Percentile and PercentileBy.
+func TestPercentile(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "min",
+			Template: "{{- percentile 0 .L -}}",
+			Args: helper.TestArgs{
+				"L": []int{15, 20, 35, 40, 50},
+			},
+			Want: "15",
+		},
+		{
+			Name:     "max",
+			Template: "{{- percentile 100 .L -}}",
+			Args: helper.TestArgs{
+				"L": []int{15, 20, 35, 40, 50},
+			},
+			Want: "50",
+		},
+		{
+			Name:     "interpolated",
+			Template: "{{- percentile 90 .L -}}",
+			Args: helper.TestArgs{
+				"L": []int{15, 20, 35, 40, 50},
+			},
+			Want: "46",
+		},
+		{
+			Name:     "single",
+			Template: "{{- percentile 90 .L -}}",
+			Args: helper.TestArgs{
+				"L": []int{15},
+			},
+			Want: "15",
+		},
+		{
+			Name:     "out of range",
+			Template: "{{- percentile 101 .L -}}",
+			Args: helper.TestArgs{
+				"L": []int{15},
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "not a number",
+			Template: `{{- percentile "NaN" .L -}}`,
+			Args: helper.TestArgs{
+				"L": []int{1, 2, 3},
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "bad percentile",
+			Template: `{{- percentile "x" .L -}}`,
+			Args: helper.TestArgs{
+				"L": []int{15},
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "not a list",
+			Template: "{{- percentile 50 5 -}}",
+			WantErr:  true,
+		},
+		{
+			Name:     "by key",
+			Template: `{{- percentileBy 25 "size.w" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			Want: "1.75",
+		},
+		{
+			Name:     "by bad percentile",
+			Template: `{{- percentileBy "x" "size.w" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "by missing key",
+			Template: `{{- percentileBy 25 "weight" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestPercentileNaN ensures that a NaN percentile is reported as an error, rather than causing a panic
+func TestPercentileNaN(t *testing.T) {
+	_, err := Percentile("NaN", []int{1, 2, 3})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not between 0 and 100")
+}
+
+// TestStandardDeviation provides unit test coverage for StandardDeviation and StandardDeviationBy.
+func TestStandardDeviation(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "numbers",
+			Template: "{{- stddev .L -}}",
+			Args: helper.TestArgs{
+				"L": []int{2, 4, 4, 4, 5, 5, 7, 9},
+			},
+			Want: "2",
+		},
+		{
+			Name:     "empty",
+			Template: "{{- stddev .L -}}",
+			Args: helper.TestArgs{
+				"L": []int{},
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "not a list",
+			Template: "{{- stddev 5 -}}",
+			WantErr:  true,
+		},
+		{
+			Name:     "by key",
+			Template: `{{- stddevBy "size.w" .L | round 4 -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			Want: "1.118",
+		},
+		{
+			Name:     "by missing key",
+			Template: `{{- stddevBy "weight" .L -}}`,
+			Args: helper.TestArgs{
+				"L": items(t),
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
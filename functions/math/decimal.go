@@ -0,0 +1,287 @@
+package math
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an arbitrary precision decimal number, suitable for currency calculations.
+// When printed, it shows all significant digits without using scientific notation
+type Decimal struct {
+	r *big.Rat
+}
+
+// RoundingMode selects how a Decimal is rounded to a given number of places
+type RoundingMode string
+
+// The supported rounding modes
+const (
+	RoundHalfUp   RoundingMode = "halfUp"   // to nearest, ties away from zero
+	RoundHalfDown RoundingMode = "halfDown" // to nearest, ties towards zero
+	RoundHalfEven RoundingMode = "halfEven" // to nearest, ties to the even neighbour (banker's rounding)
+	RoundUp       RoundingMode = "up"       // away from zero
+	RoundDown     RoundingMode = "down"     // towards zero (truncation)
+	RoundCeiling  RoundingMode = "ceiling"  // towards positive infinity
+	RoundFloor    RoundingMode = "floor"    // towards negative infinity
+)
+
+// String returns the exact decimal representation of the number
+func (d Decimal) String() string {
+	if d.r == nil {
+		return "0"
+	}
+	return d.r.FloatString(d.places())
+}
+
+// places returns the number of digits after the decimal point needed to represent the number exactly,
+// (or a maximum of 32 for numbers that don't terminate)
+func (d Decimal) places() int {
+	const maxPlaces = 32
+
+	den := new(big.Int).Set(d.r.Denom())
+	var twos, fives int
+	two, five := big.NewInt(2), big.NewInt(5)
+	m := new(big.Int)
+	for ; m.Mod(den, two).Sign() == 0; twos++ {
+		den.Quo(den, two)
+	}
+	for ; m.Mod(den, five).Sign() == 0; fives++ {
+		den.Quo(den, five)
+	}
+	if den.Cmp(big.NewInt(1)) != 0 {
+		return maxPlaces
+	}
+	return max(twos, fives)
+}
+
+// MarshalJSON encodes the number as a JSON number
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// ToDecimal converts the given value to a Decimal. Strings are parsed exactly,
+// and floats are converted using the shortest representation that round-trips (so 0.1 is exactly 0.1)
+func ToDecimal(a any) (Decimal, error) {
+	var s string
+	switch v := a.(type) {
+	case Decimal:
+		if v.r == nil {
+			return Decimal{r: new(big.Rat)}, nil
+		}
+		return v, nil
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case json.Number:
+		s = string(v)
+	case string:
+		s = strings.TrimSpace(v)
+	case bool:
+		s = "0"
+		if v {
+			s = "1"
+		}
+	default:
+		i, err := toIntString(a)
+		if err != nil {
+			return Decimal{}, err
+		}
+		s = i
+	}
+
+	if err := checkDecimalSize(s); err != nil {
+		return Decimal{}, err
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || strings.Contains(s, "/") {
+		return Decimal{}, fmt.Errorf("decimal: can't convert %q to a decimal", s)
+	}
+	return Decimal{r: r}, nil
+}
+
+// The limits on the size of numbers given as text, so that converting them doesn't take unreasonable amounts of memory.
+// These are well beyond the range of a float64
+const (
+	maxDecimalDigits   = 1000
+	maxDecimalExponent = 1000
+)
+
+// checkDecimalSize returns an error if the number (as text) has too many digits, or too large an exponent
+func checkDecimalSize(s string) error {
+	mantissa, exp, found := strings.Cut(strings.ToLower(s), "e")
+	if len(mantissa) > maxDecimalDigits {
+		return fmt.Errorf("decimal: %q has more than %d digits", s, maxDecimalDigits)
+	}
+	if !found {
+		return nil
+	}
+	e, err := strconv.Atoi(exp)
+	if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+		return fmt.Errorf("decimal: the exponent of %q should be a number from -%d to %d", s, maxDecimalExponent, maxDecimalExponent)
+	}
+	return nil
+}
+
+// toIntString formats any integer type as a string
+func toIntString(a any) (string, error) {
+	switch v := a.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), nil
+	}
+	return "", fmt.Errorf("decimal: can't convert from %T to decimal", a)
+}
+
+func decimalOperation(o []any, fn func(a, b *big.Rat) *big.Rat) (Decimal, error) {
+	if len(o) == 0 {
+		return Decimal{r: new(big.Rat)}, nil
+	}
+
+	acc, err := ToDecimal(o[0])
+	if err != nil {
+		return Decimal{}, err
+	}
+	res := new(big.Rat).Set(acc.r)
+	for _, v := range o[1:] {
+		d, errD := ToDecimal(v)
+		if errD != nil {
+			return Decimal{}, errD
+		}
+		res = fn(res, d.r)
+	}
+	return Decimal{r: res}, nil
+}
+
+// DecimalAdd adds zero or more operands left to right using exact decimal arithmetic
+func DecimalAdd(o ...any) (Decimal, error) {
+	return decimalOperation(o, func(a, b *big.Rat) *big.Rat {
+		return a.Add(a, b)
+	})
+}
+
+// DecimalSubtract subtracts zero or more operands left to right using exact decimal arithmetic
+func DecimalSubtract(o ...any) (Decimal, error) {
+	return decimalOperation(o, func(a, b *big.Rat) *big.Rat {
+		return a.Sub(a, b)
+	})
+}
+
+// DecimalMultiply multiplies zero or more operands left to right using exact decimal arithmetic
+func DecimalMultiply(o ...any) (Decimal, error) {
+	return decimalOperation(o, func(a, b *big.Rat) *big.Rat {
+		return a.Mul(a, b)
+	})
+}
+
+// DecimalRound rounds the value to the given number of decimal places,
+// using the optional rounding mode (default is halfUp)
+func DecimalRound(places int, value any, mode ...string) (Decimal, error) {
+	d, err := ToDecimal(value)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	m := RoundHalfUp
+	if len(mode) > 0 {
+		m = RoundingMode(mode[0])
+	}
+
+	r, err := roundRat(d.r, places, m)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{r: r}, nil
+}
+
+// DecimalFormat formats the value with exactly the given number of decimal places,
+// rounding half up if needed
+func DecimalFormat(places int, value any) (string, error) {
+	if places < 0 {
+		return "", fmt.Errorf("decFormat: places cannot be negative")
+	}
+	d, err := DecimalRound(places, value)
+	if err != nil {
+		return "", err
+	}
+	return d.r.FloatString(places), nil
+}
+
+// roundRat rounds r to the given number of decimal places using the given mode
+func roundRat(r *big.Rat, places int, mode RoundingMode) (*big.Rat, error) {
+	if !validRoundingMode(mode) {
+		return nil, fmt.Errorf("unknown rounding mode %q", mode)
+	}
+
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(places))), nil))
+	scaled := new(big.Rat).Set(r)
+	if places >= 0 {
+		scaled.Mul(scaled, scale)
+	} else {
+		scaled.Quo(scaled, scale)
+	}
+
+	// split into integer and fractional parts (truncated towards zero)
+	q, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	neg := scaled.Sign() < 0
+
+	if rem.Sign() != 0 {
+		// compare twice the remainder with the denominator to determine which side of half we're on
+		half := new(big.Int).Abs(rem)
+		half.Mul(half, big.NewInt(2))
+		c := half.Cmp(scaled.Denom())
+
+		var awayFromZero bool
+		switch mode {
+		case RoundHalfUp:
+			awayFromZero = c >= 0
+		case RoundHalfDown:
+			awayFromZero = c > 0
+		case RoundHalfEven:
+			awayFromZero = c > 0 || (c == 0 && new(big.Int).Abs(q).Bit(0) == 1)
+		case RoundUp:
+			awayFromZero = true
+		case RoundDown:
+			awayFromZero = false
+		case RoundCeiling:
+			awayFromZero = !neg
+		case RoundFloor:
+			awayFromZero = neg
+		}
+
+		if awayFromZero {
+			if neg {
+				q.Sub(q, big.NewInt(1))
+			} else {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	}
+
+	res := new(big.Rat).SetInt(q)
+	if places >= 0 {
+		res.Quo(res, scale)
+	} else {
+		res.Mul(res, scale)
+	}
+	return res, nil
+}
+
+// validRoundingMode returns true if the mode is one of the known modes
+func validRoundingMode(mode RoundingMode) bool {
+	switch mode {
+	case RoundHalfUp, RoundHalfDown, RoundHalfEven, RoundUp, RoundDown, RoundCeiling, RoundFloor:
+		return true
+	}
+	return false
+}
+
+// abs returns the magnitude of an integer
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
@@ -0,0 +1,339 @@
+package math
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestToDecimal provides unit test coverage for ToDecimal.
+func TestToDecimal(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "float",
+			Template: "{{- decimal .A -}}",
+			Args: helper.TestArgs{
+				"A": 0.1,
+			},
+			Want: "0.1",
+		},
+		{
+			Name:     "string",
+			Template: `{{- decimal "123456789012345678901234567890.123456789" -}}`,
+			Want:     "123456789012345678901234567890.123456789",
+		},
+		{
+			Name:     "large float without scientific notation",
+			Template: "{{- decimal .A -}}",
+			Args: helper.TestArgs{
+				"A": 1e21,
+			},
+			Want: "1000000000000000000000",
+		},
+		{
+			Name:     "small float without scientific notation",
+			Template: "{{- decimal .A -}}",
+			Args: helper.TestArgs{
+				"A": 1e-7,
+			},
+			Want: "0.0000001",
+		},
+		{
+			Name:     "json.Number",
+			Template: "{{- decimal .A -}}",
+			Args: helper.TestArgs{
+				"A": json.Number("19.99"),
+			},
+			Want: "19.99",
+		},
+		{
+			Name:     "int",
+			Template: "{{- decimal 42 -}}",
+			Want:     "42",
+		},
+		{
+			Name:     "bool",
+			Template: "{{- decimal true -}}",
+			Want:     "1",
+		},
+		{
+			Name:     "decimal",
+			Template: "{{- decimal (decimal 1.5) -}}",
+			Want:     "1.5",
+		},
+		{
+			Name:     "fraction string",
+			Template: `{{- decimal "1/3" -}}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "not a number",
+			Template: `{{- decimal "x" -}}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "exponent",
+			Template: `{{- decimal "1.5e3" -}}`,
+			Want:     "1500",
+		},
+		{
+			Name:     "exponent too large",
+			Template: `{{- decimal "1e999999999" -}}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "exponent too small",
+			Template: `{{- decimal "1E-1001" -}}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "too many digits",
+			Template: `{{- decimal .A -}}`,
+			Args: helper.TestArgs{
+				"A": "1" + strings.Repeat("0", 1000),
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "smallest float",
+			Template: "{{- decimal .A -}}",
+			Args: helper.TestArgs{
+				"A": 5e-324,
+			},
+			Want: "0." + strings.Repeat("0", 323) + "5",
+		},
+		{
+			Name:     "not a number type",
+			Template: `{{- decimal .A -}}`,
+			Args: helper.TestArgs{
+				"A": []int{1},
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestDecimalAdd provides unit test coverage for DecimalAdd.
+func TestDecimalAdd(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "zero",
+			Template: "{{- decAdd -}}",
+			Want:     "0",
+		},
+		{
+			Name:     "no float error",
+			Template: "{{- decAdd .A .B -}}",
+			Args: helper.TestArgs{
+				"A": 0.1,
+				"B": 0.2,
+			},
+			Want: "0.3",
+		},
+		{
+			Name:     "mixed",
+			Template: `{{- decAdd "10.05" 3 .A -}}`,
+			Args: helper.TestArgs{
+				"A": json.Number("-0.05"),
+			},
+			Want: "13",
+		},
+		{
+			Name:     "bad argument",
+			Template: `{{- decAdd 1 "x" -}}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad first argument",
+			Template: `{{- decAdd "x" 1 -}}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestDecimalSubtract provides unit test coverage for DecimalSubtract.
+func TestDecimalSubtract(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "simple",
+			Template: "{{- decSub 1 .A -}}",
+			Args: helper.TestArgs{
+				"A": 0.9,
+			},
+			Want: "0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestDecimalMultiply provides unit test coverage for DecimalMultiply.
+func TestDecimalMultiply(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "price times quantity",
+			Template: "{{- decMul .price .qty -}}",
+			Args: helper.TestArgs{
+				"price": 19.99,
+				"qty":   3,
+			},
+			Want: "59.97",
+		},
+		{
+			Name:     "tax",
+			Template: `{{- decMul "1.15" "0.1" -}}`,
+			Want:     "0.115",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestDecimalRound provides unit test coverage for DecimalRound.
+func TestDecimalRound(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "default half up",
+			Template: `{{- decRound 2 "2.345" -}}`,
+			Want:     "2.35",
+		},
+		{
+			Name:     "negative half up",
+			Template: `{{- decRound 2 "-2.345" -}}`,
+			Want:     "-2.35",
+		},
+		{
+			Name:     "half down",
+			Template: `{{- decRound 2 "2.345" "halfDown" -}}`,
+			Want:     "2.34",
+		},
+		{
+			Name:     "half even down",
+			Template: `{{- decRound 2 "2.345" "halfEven" -}}`,
+			Want:     "2.34",
+		},
+		{
+			Name:     "half even up",
+			Template: `{{- decRound 2 "2.355" "halfEven" -}}`,
+			Want:     "2.36",
+		},
+		{
+			Name:     "half even negative",
+			Template: `{{- decRound 0 "-3.5" "halfEven" -}}`,
+			Want:     "-4",
+		},
+		{
+			Name:     "up",
+			Template: `{{- decRound 1 "-2.01" "up" -}}`,
+			Want:     "-2.1",
+		},
+		{
+			Name:     "down",
+			Template: `{{- decRound 1 "2.09" "down" -}}`,
+			Want:     "2",
+		},
+		{
+			Name:     "ceiling",
+			Template: `{{- decRound 1 "-2.09" "ceiling" -}}`,
+			Want:     "-2",
+		},
+		{
+			Name:     "floor",
+			Template: `{{- decRound 1 "-2.01" "floor" -}}`,
+			Want:     "-2.1",
+		},
+		{
+			Name:     "negative places",
+			Template: `{{- decRound -2 "1250" -}}`,
+			Want:     "1300",
+		},
+		{
+			Name:     "exact",
+			Template: `{{- decRound 2 "1.5" "floor" -}}`,
+			Want:     "1.5",
+		},
+		{
+			Name:     "bad mode",
+			Template: `{{- decRound 2 "1.5" "sideways" -}}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad value",
+			Template: `{{- decRound 2 "x" -}}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestDecimalFormat provides unit test coverage for DecimalFormat.
+func TestDecimalFormat(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "pads",
+			Template: `{{- decFormat 2 "3" -}}`,
+			Want:     "3.00",
+		},
+		{
+			Name:     "rounds",
+			Template: `{{- decAdd .A .B | decFormat 2 -}}`,
+			Args: helper.TestArgs{
+				"A": 1.005,
+				"B": 0,
+			},
+			Want: "1.01",
+		},
+		{
+			Name:     "huge",
+			Template: `{{- decFormat 1 .A -}}`,
+			Args: helper.TestArgs{
+				"A": 1e25,
+			},
+			Want: "10000000000000000000000000.0",
+		},
+		{
+			Name:     "negative places",
+			Template: `{{- decFormat -1 "3" -}}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad value",
+			Template: `{{- decFormat 1 "x" -}}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestDecimalString provides unit test coverage for Decimal.String and Decimal.MarshalJSON.
+func TestDecimalString(t *testing.T) {
+	assert.Equal(t, "0", Decimal{}.String())
+
+	d, err := ToDecimal("12.50")
+	require.NoError(t, err)
+	b, err := json.Marshal(map[string]any{"price": d})
+	require.NoError(t, err)
+	assert.Equal(t, `{"price":12.5}`, string(b))
+}
@@ -0,0 +1,153 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// ErrOverflow is returned when the result of an integer operation can't be represented in 64 bits
+var ErrOverflow = errors.New("integer overflow")
+
+// ErrDivideByZero is returned when an integer division or modulus has a zero divisor
+var ErrDivideByZero = errors.New("integer divide by zero")
+
+// intReducer is the signature for an integer operation that can fail
+type intReducer func(a, b int64) (int64, error)
+
+func intOperation(o []any, fn intReducer) (int64, error) {
+	a, err := helper.Apply(o, helper.ToInt)
+	if err != nil {
+		return 0, err
+	}
+	if len(a) == 0 {
+		return 0, nil
+	}
+
+	acc := a[0]
+	for _, v := range a[1:] {
+		acc, err = fn(acc, v)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return acc, nil
+}
+
+// AddInt adds zero or more integer operands left to right. The result of an AddInt with no arguments is 0
+func AddInt(o ...any) (int64, error) {
+	return intOperation(o, addInt)
+}
+
+// SubtractInt subtracts zero or more integer operands left to right. The result of a SubtractInt with no arguments is 0
+func SubtractInt(o ...any) (int64, error) {
+	return intOperation(o, func(a, b int64) (int64, error) {
+		if b == math.MinInt64 {
+			if a >= 0 {
+				return 0, fmt.Errorf("%d - %d: %w", a, b, ErrOverflow)
+			}
+			return a - b, nil
+		}
+		return addInt(a, -b)
+	})
+}
+
+// MultiplyInt multiplies zero or more integer operands left to right. The result of a MultiplyInt with no arguments is 0
+func MultiplyInt(o ...any) (int64, error) {
+	return intOperation(o, multiplyInt)
+}
+
+// IntDivide divides zero or more integer operands left to right, discarding any remainder.
+// The result of an IntDivide with no arguments is 0
+func IntDivide(o ...any) (int64, error) {
+	return intOperation(o, func(a, b int64) (int64, error) {
+		if b == 0 {
+			return 0, fmt.Errorf("%d / %d: %w", a, b, ErrDivideByZero)
+		}
+		if a == math.MinInt64 && b == -1 {
+			return 0, fmt.Errorf("%d / %d: %w", a, b, ErrOverflow)
+		}
+		return a / b, nil
+	})
+}
+
+// Modulo returns the remainder of dividing a by b. The result has the same sign as a
+func Modulo(a, b any) (int64, error) {
+	x, err := helper.ToInt(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := helper.ToInt(b)
+	if err != nil {
+		return 0, err
+	}
+	if y == 0 {
+		return 0, fmt.Errorf("%d %% %d: %w", x, y, ErrDivideByZero)
+	}
+	if y == -1 {
+		return 0, nil
+	}
+	return x % y, nil
+}
+
+// Power raises base to the (non-negative) integer power exp
+func Power(base, exp any) (int64, error) {
+	b, err := helper.ToInt(base)
+	if err != nil {
+		return 0, err
+	}
+	e, err := helper.ToInt(exp)
+	if err != nil {
+		return 0, err
+	}
+	if e < 0 {
+		return 0, fmt.Errorf("pow: negative exponent %d", e)
+	}
+
+	switch b {
+	case 0:
+		if e == 0 {
+			return 1, nil
+		}
+		return 0, nil
+	case 1:
+		return 1, nil
+	case -1:
+		if e%2 == 0 {
+			return 1, nil
+		}
+		return -1, nil
+	}
+
+	var res int64 = 1
+	for c := int64(0); c < e; c++ {
+		res, err = multiplyInt(res, b)
+		if err != nil {
+			return 0, fmt.Errorf("%d ** %d: %w", b, e, ErrOverflow)
+		}
+	}
+	return res, nil
+}
+
+// addInt adds two integers, checking for overflow
+func addInt(a, b int64) (int64, error) {
+	c := a + b
+	if (c > a) != (b > 0) {
+		return 0, fmt.Errorf("%d + %d: %w", a, b, ErrOverflow)
+	}
+	return c, nil
+}
+
+// multiplyInt multiplies two integers, checking for overflow
+func multiplyInt(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, fmt.Errorf("%d * %d: %w", a, b, ErrOverflow)
+	}
+	return c, nil
+}
@@ -0,0 +1,310 @@
+package math
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// TestAddInt provides unit test coverage for AddInt.
+func TestAddInt(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "zero",
+			Template: "{{- addInt -}}",
+			Want:     "0",
+		},
+		{
+			Name:     "three",
+			Template: "{{- addInt .A .B .C -}}",
+			Args: helper.TestArgs{
+				"A": 1,
+				"B": int64(2),
+				"C": 3.0,
+			},
+			Want: "6",
+		},
+		{
+			Name:     "large ids keep precision",
+			Template: "{{- addInt .A 1 -}}",
+			Args: helper.TestArgs{
+				"A": json.Number("9007199254740993"),
+			},
+			Want: "9007199254740994",
+		},
+		{
+			Name:     "string",
+			Template: "{{- addInt .A 1 -}}",
+			Args: helper.TestArgs{
+				"A": "41",
+			},
+			Want: "42",
+		},
+		{
+			Name:     "fractional",
+			Template: "{{- addInt .A 1 -}}",
+			Args: helper.TestArgs{
+				"A": 1.5,
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "overflow",
+			Template: "{{- addInt .A 1 -}}",
+			Args: helper.TestArgs{
+				"A": int64(9223372036854775807),
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "uint overflow",
+			Template: "{{- addInt .A -}}",
+			Args: helper.TestArgs{
+				"A": uint64(9223372036854775808),
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "not a number",
+			Template: "{{- addInt .A -}}",
+			Args: helper.TestArgs{
+				"A": []int{1},
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestSubtractInt provides unit test coverage for SubtractInt.
+func TestSubtractInt(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "three",
+			Template: "{{- subInt 10 3 2 -}}",
+			Want:     "5",
+		},
+		{
+			Name:     "underflow",
+			Template: "{{- subInt .A 1 -}}",
+			Args: helper.TestArgs{
+				"A": int64(-9223372036854775808),
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "min int",
+			Template: "{{- subInt -1 .A -}}",
+			Args: helper.TestArgs{
+				"A": int64(-9223372036854775808),
+			},
+			Want: "9223372036854775807",
+		},
+		{
+			Name:     "min int overflow",
+			Template: "{{- subInt 0 .A -}}",
+			Args: helper.TestArgs{
+				"A": int64(-9223372036854775808),
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestMultiplyInt provides unit test coverage for MultiplyInt.
+func TestMultiplyInt(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "three",
+			Template: "{{- multInt 2 3 -4 -}}",
+			Want:     "-24",
+		},
+		{
+			Name:     "zero",
+			Template: "{{- multInt .A 0 -}}",
+			Args: helper.TestArgs{
+				"A": int64(9223372036854775807),
+			},
+			Want: "0",
+		},
+		{
+			Name:     "overflow",
+			Template: "{{- multInt .A 2 -}}",
+			Args: helper.TestArgs{
+				"A": int64(4611686018427387904),
+			},
+			WantErr: true,
+		},
+		{
+			Name:     "min int by -1",
+			Template: "{{- multInt .A -1 -}}",
+			Args: helper.TestArgs{
+				"A": int64(-9223372036854775808),
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestIntDivide provides unit test coverage for IntDivide.
+func TestIntDivide(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "whole",
+			Template: "{{- idiv 7 2 -}}",
+			Want:     "3",
+		},
+		{
+			Name:     "negative truncates towards zero",
+			Template: "{{- idiv -7 2 -}}",
+			Want:     "-3",
+		},
+		{
+			Name:     "float args",
+			Template: "{{- idiv .A .B -}}",
+			Args: helper.TestArgs{
+				"A": 100.0,
+				"B": 7.0,
+			},
+			Want: "14",
+		},
+		{
+			Name:     "divide by zero",
+			Template: "{{- idiv 7 0 -}}",
+			WantErr:  true,
+		},
+		{
+			Name:     "overflow",
+			Template: "{{- idiv .A -1 -}}",
+			Args: helper.TestArgs{
+				"A": int64(-9223372036854775808),
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestModulo provides unit test coverage for Modulo.
+func TestModulo(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "positive",
+			Template: "{{- mod 7 3 -}}",
+			Want:     "1",
+		},
+		{
+			Name:     "negative",
+			Template: "{{- mod -7 3 -}}",
+			Want:     "-1",
+		},
+		{
+			Name:     "min int by -1",
+			Template: "{{- mod .A -1 -}}",
+			Args: helper.TestArgs{
+				"A": int64(-9223372036854775808),
+			},
+			Want: "0",
+		},
+		{
+			Name:     "divide by zero",
+			Template: "{{- mod 7 0 -}}",
+			WantErr:  true,
+		},
+		{
+			Name:     "bad dividend",
+			Template: `{{- mod "x" 2 -}}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad divisor",
+			Template: `{{- mod 2 "x" -}}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestPower provides unit test coverage for Power.
+func TestPower(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "simple",
+			Template: "{{- pow 2 10 -}}",
+			Want:     "1024",
+		},
+		{
+			Name:     "zero exponent",
+			Template: "{{- pow 5 0 -}}",
+			Want:     "1",
+		},
+		{
+			Name:     "zero base",
+			Template: "{{- pow 0 5 -}}",
+			Want:     "0",
+		},
+		{
+			Name:     "one",
+			Template: "{{- pow 1 .A -}}",
+			Args: helper.TestArgs{
+				"A": int64(9223372036854775807),
+			},
+			Want: "1",
+		},
+		{
+			Name:     "minus one",
+			Template: "{{- pow -1 .A -}}",
+			Args: helper.TestArgs{
+				"A": int64(9223372036854775807),
+			},
+			Want: "-1",
+		},
+		{
+			Name:     "negative base",
+			Template: "{{- pow -3 3 -}}",
+			Want:     "-27",
+		},
+		{
+			Name:     "overflow",
+			Template: "{{- pow 2 63 -}}",
+			WantErr:  true,
+		},
+		{
+			Name:     "negative exponent",
+			Template: "{{- pow 2 -1 -}}",
+			WantErr:  true,
+		},
+		{
+			Name:     "bad base",
+			Template: `{{- pow "x" 2 -}}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad exponent",
+			Template: `{{- pow 2 "x" -}}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -2,6 +2,8 @@
 package math
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"text/template"
 
@@ -11,11 +13,39 @@ import (
 // Functions operate on numerical data
 func Functions() template.FuncMap {
 	return template.FuncMap{
-		"add":  Add,
-		"sub":  Subtract,
-		"mult": Multiply,
-		"div":  Divide,
-		"abs":  AbsoluteValue,
+		"add":          Add,
+		"sub":          Subtract,
+		"mult":         Multiply,
+		"div":          Divide,
+		"abs":          AbsoluteValue,
+		"round":        Round,
+		"floor":        Floor,
+		"ceil":         Ceil,
+		"min":          Minimum,
+		"max":          Maximum,
+		"clamp":        Clamp,
+		"addInt":       AddInt,
+		"subInt":       SubtractInt,
+		"multInt":      MultiplyInt,
+		"idiv":         IntDivide,
+		"mod":          Modulo,
+		"pow":          Power,
+		"decimal":      ToDecimal,
+		"decAdd":       DecimalAdd,
+		"decSub":       DecimalSubtract,
+		"decMul":       DecimalMultiply,
+		"decRound":     DecimalRound,
+		"decFormat":    DecimalFormat,
+		"sum":          Sum,
+		"sumBy":        SumBy,
+		"avg":          Average,
+		"avgBy":        AverageBy,
+		"median":       Median,
+		"medianBy":     MedianBy,
+		"percentile":   Percentile,
+		"percentileBy": PercentileBy,
+		"stddev":       StandardDeviation,
+		"stddevBy":     StandardDeviationBy,
 	}
 }
 
@@ -67,3 +97,93 @@ func AbsoluteValue(o any) (float64, error) {
 	}
 	return math.Abs(v), nil
 }
+
+// maxRoundPlaces is the most decimal places (either way) that values can be rounded to, as 10 to any
+// higher power is outside the range of a float64
+const maxRoundPlaces = 308
+
+// Round the given value to the given number of decimal places, with halves rounded away from zero
+func Round(places int, o any) (float64, error) {
+	v, err := helper.ToFloat(o)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case places > maxRoundPlaces:
+		return v, nil
+	case places < -maxRoundPlaces:
+		return 0, nil
+	}
+
+	scale := math.Pow(10, float64(places))
+	scaled := v * scale
+	if math.IsInf(scaled, 0) || math.Abs(scaled) >= 1<<52 { // already a whole number at this scale
+		return v, nil
+	}
+	return math.Round(scaled) / scale, nil
+}
+
+// Floor returns the greatest integer value less than or equal to the given value
+func Floor(o any) (float64, error) {
+	v, err := helper.ToFloat(o)
+	if err != nil {
+		return 0, err
+	}
+	return math.Floor(v), nil
+}
+
+// Ceil returns the least integer value greater than or equal to the given value
+func Ceil(o any) (float64, error) {
+	v, err := helper.ToFloat(o)
+	if err != nil {
+		return 0, err
+	}
+	return math.Ceil(v), nil
+}
+
+// Minimum returns the smallest of the given values. Any lists given have their items included
+func Minimum(o ...any) (float64, error) {
+	return extreme(o, math.Min)
+}
+
+// Maximum returns the largest of the given values. Any lists given have their items included
+func Maximum(o ...any) (float64, error) {
+	return extreme(o, math.Max)
+}
+
+// Clamp returns the value limited to be within the range lower to upper (inclusive)
+func Clamp(lower, upper, o any) (float64, error) {
+	a, err := helper.Apply([]any{lower, upper, o}, helper.ToFloat)
+	if err != nil {
+		return 0, err
+	}
+	if a[0] > a[1] {
+		return 0, fmt.Errorf("clamp: lower bound %v is greater than upper bound %v", a[0], a[1])
+	}
+	return math.Min(math.Max(a[2], a[0]), a[1]), nil
+}
+
+// extreme is the helper for Minimum and Maximum
+func extreme(o []any, fn func(a, b float64) float64) (float64, error) {
+	var values []float64
+	for _, item := range o {
+		if _, _, err := helper.ListInfo(item); err == nil {
+			f, errL := toFloatList(item)
+			if errL != nil {
+				return 0, errL
+			}
+			values = append(values, f...)
+			continue
+		}
+		f, err := helper.ToFloat(item)
+		if err != nil {
+			return 0, err
+		}
+		values = append(values, f)
+	}
+
+	if len(values) == 0 {
+		return 0, errors.New("no values given")
+	}
+	return helper.Reduce(values[1:], values[0], fn), nil
+}
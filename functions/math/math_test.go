@@ -1,6 +1,7 @@
 package math
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/mantidtech/tplr/functions/helper"
@@ -10,7 +11,7 @@ import (
 // TestFunctions provides unit test coverage for Functions.
 func TestFunctions(t *testing.T) {
 	fn := Functions()
-	assert.Len(t, fn, 5, "weakly ensuring functions haven't been added/removed without updating tests")
+	assert.Len(t, fn, 33, "weakly ensuring functions haven't been added/removed without updating tests")
 }
 
 // TestAdd provides unit test coverage for Add.
@@ -82,6 +83,26 @@ func TestAdd(t *testing.T) {
 			Want:    "97",
 			WantErr: false,
 		},
+		{
+			Name:     "int64 and uint",
+			Template: "{{- add .A .B -}}",
+			Args: helper.TestArgs{
+				"A": int64(5),
+				"B": uint(6),
+			},
+			Want:    "11",
+			WantErr: false,
+		},
+		{
+			Name:     "json.Number",
+			Template: "{{- add .A .B -}}",
+			Args: helper.TestArgs{
+				"A": json.Number("1.5"),
+				"B": json.Number("2"),
+			},
+			Want:    "3.5",
+			WantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -379,3 +400,245 @@ func TestAbs(t *testing.T) {
 		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
 	}
 }
+
+// TestRound provides unit test coverage for Round.
+func TestRound(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "whole",
+			Template: "{{- round 0 .A -}}",
+			Args: helper.TestArgs{
+				"A": 2.5,
+			},
+			Want: "3",
+		},
+		{
+			Name:     "places",
+			Template: "{{- .A | round 2 -}}",
+			Args: helper.TestArgs{
+				"A": 3.14159,
+			},
+			Want: "3.14",
+		},
+		{
+			Name:     "negative",
+			Template: "{{- round 1 .A -}}",
+			Args: helper.TestArgs{
+				"A": -1.25,
+			},
+			Want: "-1.3",
+		},
+		{
+			Name:     "negative places",
+			Template: "{{- round -2 .A -}}",
+			Args: helper.TestArgs{
+				"A": 1250,
+			},
+			Want: "1300",
+		},
+		{
+			Name:     "more places than a float has",
+			Template: "{{- round 400 .A -}}",
+			Args: helper.TestArgs{
+				"A": 1.5,
+			},
+			Want: "1.5",
+		},
+		{
+			Name:     "fewer places than a float has",
+			Template: "{{- round -400 .A -}}",
+			Args: helper.TestArgs{
+				"A": 1.5e300,
+			},
+			Want: "0",
+		},
+		{
+			Name:     "at the limit",
+			Template: "{{- round 308 .A }} {{ round -308 .B -}}",
+			Args: helper.TestArgs{
+				"A": 1.25,
+				"B": 4e307,
+			},
+			Want: "1.25 0",
+		},
+		{
+			Name:     "large value",
+			Template: "{{- round 300 .A -}}",
+			Args: helper.TestArgs{
+				"A": 1e10,
+			},
+			Want: "1e+10",
+		},
+		{
+			Name:     "not a number",
+			Template: `{{- round 1 "x" -}}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestFloor provides unit test coverage for Floor.
+func TestFloor(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "positive",
+			Template: "{{- floor 2.7 -}}",
+			Want:     "2",
+		},
+		{
+			Name:     "negative",
+			Template: "{{- floor -2.2 -}}",
+			Want:     "-3",
+		},
+		{
+			Name:     "not a number",
+			Template: `{{- floor "x" -}}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestCeil provides unit test coverage for Ceil.
+func TestCeil(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "positive",
+			Template: "{{- ceil 2.2 -}}",
+			Want:     "3",
+		},
+		{
+			Name:     "negative",
+			Template: "{{- ceil -2.7 -}}",
+			Want:     "-2",
+		},
+		{
+			Name:     "not a number",
+			Template: `{{- ceil "x" -}}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestMinimum provides unit test coverage for Minimum.
+func TestMinimum(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "args",
+			Template: "{{- min 3 1.5 2 -}}",
+			Want:     "1.5",
+		},
+		{
+			Name:     "list",
+			Template: "{{- min .L -}}",
+			Args: helper.TestArgs{
+				"L": []any{4.0, -1.0, 7.0},
+			},
+			Want: "-1",
+		},
+		{
+			Name:     "list and args",
+			Template: "{{- min .L -5 -}}",
+			Args: helper.TestArgs{
+				"L": []int{4, -1, 7},
+			},
+			Want: "-5",
+		},
+		{
+			Name:     "none",
+			Template: "{{- min -}}",
+			WantErr:  true,
+		},
+		{
+			Name:     "not a number",
+			Template: `{{- min 1 "x" -}}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "list with not a number",
+			Template: `{{- min .L -}}`,
+			Args: helper.TestArgs{
+				"L": []any{1, "x"},
+			},
+			WantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestMaximum provides unit test coverage for Maximum.
+func TestMaximum(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "args",
+			Template: "{{- max 3 1.5 2 -}}",
+			Want:     "3",
+		},
+		{
+			Name:     "list",
+			Template: "{{- max .L -}}",
+			Args: helper.TestArgs{
+				"L": []any{4.0, -1.0, 7.0},
+			},
+			Want: "7",
+		},
+		{
+			Name:     "none",
+			Template: "{{- max -}}",
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestClamp provides unit test coverage for Clamp.
+func TestClamp(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "within",
+			Template: "{{- clamp 0 10 5 -}}",
+			Want:     "5",
+		},
+		{
+			Name:     "below",
+			Template: "{{- clamp 0 10 -5 -}}",
+			Want:     "0",
+		},
+		{
+			Name:     "above",
+			Template: "{{- 15 | clamp 0 10 -}}",
+			Want:     "10",
+		},
+		{
+			Name:     "bad bounds",
+			Template: "{{- clamp 10 0 5 -}}",
+			WantErr:  true,
+		},
+		{
+			Name:     "not a number",
+			Template: `{{- clamp 0 10 "x" -}}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
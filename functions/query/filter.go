@@ -0,0 +1,287 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// filterExpr is a node in a parsed filter expression
+type filterExpr interface {
+	eval(current any) any
+}
+
+// pathOperand looks up a value relative to the current node (@)
+type pathOperand struct {
+	steps []helper.PathStep
+}
+
+// missing is returned when a path operand doesn't exist, so that it doesn't compare equal to null
+type missing struct{}
+
+func (p pathOperand) eval(current any) any {
+	v, ok := helper.Follow(p.steps, current)
+	if !ok {
+		return missing{}
+	}
+	return v
+}
+
+// literal is a constant value
+type literal struct {
+	value any
+}
+
+func (l literal) eval(any) any {
+	return l.value
+}
+
+// notExpr negates its operand
+type notExpr struct {
+	operand filterExpr
+}
+
+func (n notExpr) eval(current any) any {
+	return !truthy(n.operand.eval(current))
+}
+
+// binaryExpr applies an operator to two operands
+type binaryExpr struct {
+	op          string
+	left, right filterExpr
+}
+
+func (b binaryExpr) eval(current any) any {
+	switch b.op {
+	case "&&":
+		return truthy(b.left.eval(current)) && truthy(b.right.eval(current))
+	case "||":
+		return truthy(b.left.eval(current)) || truthy(b.right.eval(current))
+	}
+	return compare(b.op, b.left.eval(current), b.right.eval(current))
+}
+
+// truthy decides if a filter result should be considered true
+func truthy(v any) bool {
+	switch t := v.(type) {
+	case missing, nil:
+		return false
+	case bool:
+		return t
+	}
+	return true
+}
+
+// compare two values with a comparison operator. Numbers are compared numerically, strings lexically,
+// and anything else can only be tested for equality
+func compare(op string, a, b any) bool {
+	if _, ok := a.(missing); ok {
+		return false
+	}
+	if _, ok := b.(missing); ok {
+		return false
+	}
+
+	var c int
+	ordered := true
+	fa, errA := numeric(a)
+	fb, errB := numeric(b)
+	sa, okA := a.(string)
+	sb, okB := b.(string)
+
+	switch {
+	case errA == nil && errB == nil:
+		c = cmp(fa, fb)
+	case okA && okB:
+		c = strings.Compare(sa, sb)
+	default:
+		ordered = false
+		if reflect.DeepEqual(a, b) {
+			c = 0
+		} else {
+			c = 1
+		}
+	}
+
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return ordered && c < 0
+	case "<=":
+		return ordered && c <= 0
+	case ">":
+		return ordered && c > 0
+	case ">=":
+		return ordered && c >= 0
+	}
+	return false
+}
+
+// cmp returns -1, 0 or 1 as a is less than, equal to or greater than b
+func cmp(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// numeric converts a value to a float only if it is a number type
+func numeric(v any) (float64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("nil is not a number")
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return reflect.ValueOf(v).Convert(reflect.TypeOf(float64(0))).Float(), nil
+	}
+	return 0, fmt.Errorf("%T is not a number", v)
+}
+
+// filterParser is a recursive descent parser for filter expressions
+type filterParser struct {
+	src string
+	at  int
+}
+
+// parseFilter parses the content of a ?(...) filter
+func parseFilter(src string) (filterExpr, error) {
+	p := &filterParser{src: src}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.at < len(p.src) {
+		return nil, fmt.Errorf("unexpected %q in filter %q", p.src[p.at:], src)
+	}
+	return e, nil
+}
+
+func (p *filterParser) skipSpace() {
+	for p.at < len(p.src) && p.src[p.at] == ' ' {
+		p.at++
+	}
+}
+
+// accept consumes tok if it is next in the input
+func (p *filterParser) accept(tok string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.at:], tok) {
+		p.at += len(tok)
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.accept("||") {
+		var right filterExpr
+		right, err = p.parseAnd()
+		left = binaryExpr{op: "||", left: left, right: right}
+	}
+	return left, err
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	left, err := p.parseUnary()
+	for err == nil && p.accept("&&") {
+		var right filterExpr
+		right, err = p.parseUnary()
+		left = binaryExpr{op: "&&", left: left, right: right}
+	}
+	return left, err
+}
+
+func (p *filterParser) parseUnary() (filterExpr, error) {
+	if p.accept("!") {
+		e, err := p.parseUnary()
+		return notExpr{operand: e}, err
+	}
+	if p.accept("(") {
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("missing ')' in filter %q", p.src)
+		}
+		return e, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterExpr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.accept(op) {
+			right, errR := p.parseOperand()
+			return binaryExpr{op: op, left: left, right: right}, errR
+		}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseOperand() (filterExpr, error) {
+	p.skipSpace()
+	if p.at >= len(p.src) {
+		return nil, fmt.Errorf("unexpected end of filter %q", p.src)
+	}
+
+	start := p.at
+	switch c := p.src[p.at]; {
+	case c == '@':
+		p.at++
+		for p.at < len(p.src) && !strings.ContainsRune(" !=<>&|)", rune(p.src[p.at])) {
+			if p.src[p.at] == '[' {
+				end, err := matchingBracket(p.src, p.at)
+				if err != nil {
+					return nil, err
+				}
+				p.at = end
+			}
+			p.at++
+		}
+		steps, err := helper.ParsePath(p.src[start+1 : p.at])
+		return pathOperand{steps: steps}, err
+	case c == '\'' || c == '"':
+		end := strings.IndexByte(p.src[p.at+1:], c)
+		if end == -1 {
+			return nil, fmt.Errorf("unterminated string in filter %q", p.src)
+		}
+		p.at += end + 2
+		return literal{value: p.src[start+1 : p.at-1]}, nil
+	}
+
+	for p.at < len(p.src) && !strings.ContainsRune(" !=<>&|()", rune(p.src[p.at])) {
+		p.at++
+	}
+	word := p.src[start:p.at]
+	switch word {
+	case "true":
+		return literal{value: true}, nil
+	case "false":
+		return literal{value: false}, nil
+	case "null":
+		return literal{value: nil}, nil
+	}
+	f, err := strconv.ParseFloat(word, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected %q in filter %q", word, p.src)
+	}
+	return literal{value: f}, nil
+}
@@ -0,0 +1,390 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// JSONPath is a compiled JSONPath expression
+//
+// The supported subset is:
+//
+//	$            the root of the data (optional)
+//	.name        a child key
+//	['name']     a child key (allowing any characters)
+//	.*  [*]      all children
+//	..           recursive descent, eg $..name
+//	[n]          a list index, negative values count from the end
+//	[start:end]  a list slice, with an optional :step
+//	[a,b]        a union of indexes or keys
+//	[?(expr)]    a filter, eg [?(@.price < 10 && @.tag == 'sale')]
+type JSONPath struct {
+	path     string
+	segments []segment
+}
+
+// segment is a single selection step in a path
+type segment struct {
+	recursive bool
+	selector  selector
+}
+
+// selector chooses zero or more children of a node
+type selector interface {
+	selectFrom(node any, out []any) []any
+}
+
+// Compile parses a JSONPath expression
+func Compile(path string) (*JSONPath, error) {
+	p := strings.TrimSpace(path)
+	p = strings.TrimPrefix(p, "$")
+	if p != "" && p[0] != '.' && p[0] != '[' {
+		p = "." + p
+	}
+
+	q := &JSONPath{path: path}
+	at := 0
+	for at < len(p) {
+		var seg segment
+		var err error
+
+		switch {
+		case strings.HasPrefix(p[at:], ".."):
+			seg.recursive = true
+			at += 2
+			if at < len(p) && p[at] == '[' {
+				seg.selector, at, err = parseBracketSelector(p, at)
+			} else {
+				seg.selector, at, err = parseDotSelector(p, at)
+			}
+		case p[at] == '.':
+			seg.selector, at, err = parseDotSelector(p, at+1)
+		case p[at] == '[':
+			seg.selector, at, err = parseBracketSelector(p, at)
+		default:
+			err = fmt.Errorf("unexpected %q at position %d", p[at], at)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid query %q: %w", path, err)
+		}
+		q.segments = append(q.segments, seg)
+	}
+
+	return q, nil
+}
+
+// String returns the original expression
+func (q *JSONPath) String() string {
+	return q.path
+}
+
+// Apply the path to the data, returning all matching values
+func (q *JSONPath) Apply(data any) []any {
+	nodes := []any{data}
+	for _, s := range q.segments {
+		var next []any
+		for _, n := range nodes {
+			if s.recursive {
+				for _, d := range descendants(n, nil) {
+					next = s.selector.selectFrom(d, next)
+				}
+			} else {
+				next = s.selector.selectFrom(n, next)
+			}
+		}
+		nodes = next
+	}
+
+	if nodes == nil {
+		return []any{}
+	}
+	return nodes
+}
+
+// parseDotSelector reads a name or wildcard following a '.'
+func parseDotSelector(p string, at int) (selector, int, error) {
+	if at < len(p) && p[at] == '*' {
+		return wildcardSelector{}, at + 1, nil
+	}
+	end := at
+	for end < len(p) && p[end] != '.' && p[end] != '[' {
+		end++
+	}
+	if end == at {
+		return nil, at, fmt.Errorf("expected a key at position %d", at)
+	}
+	return nameSelector(p[at:end]), end, nil
+}
+
+// parseBracketSelector reads the selector contained within [...]
+func parseBracketSelector(p string, at int) (selector, int, error) {
+	end, err := matchingBracket(p, at)
+	if err != nil {
+		return nil, at, err
+	}
+	inner := strings.TrimSpace(p[at+1 : end])
+
+	if strings.HasPrefix(inner, "?") {
+		expr := strings.TrimSpace(inner[1:])
+		if !strings.HasPrefix(expr, "(") || !strings.HasSuffix(expr, ")") {
+			return nil, at, fmt.Errorf("filter must be of the form ?(expression)")
+		}
+		f, errF := parseFilter(expr[1 : len(expr)-1])
+		if errF != nil {
+			return nil, at, errF
+		}
+		return filterSelector{expr: f}, end + 1, nil
+	}
+
+	var union unionSelector
+	for _, part := range splitTopLevel(inner, ',') {
+		s, errS := parseSimpleSelector(strings.TrimSpace(part))
+		if errS != nil {
+			return nil, at, errS
+		}
+		union = append(union, s)
+	}
+	if len(union) == 1 {
+		return union[0], end + 1, nil
+	}
+	return union, end + 1, nil
+}
+
+// parseSimpleSelector reads a single wildcard, key, index or slice
+func parseSimpleSelector(s string) (selector, error) {
+	switch {
+	case s == "*":
+		return wildcardSelector{}, nil
+	case len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]:
+		return nameSelector(s[1 : len(s)-1]), nil
+	case strings.Contains(s, ":"):
+		return parseSlice(s)
+	}
+
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, fmt.Errorf("expected an index, slice, wildcard or quoted key, got %q", s)
+	}
+	return indexSelector(i), nil
+}
+
+// parseSlice reads a [start:end:step] selector
+func parseSlice(s string) (selector, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid slice %q", s)
+	}
+
+	sl := sliceSelector{step: 1}
+	var err error
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		var n int
+		n, err = strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slice %q", s)
+		}
+		switch i {
+		case 0:
+			sl.start = &n
+		case 1:
+			sl.end = &n
+		case 2:
+			sl.step = n
+		}
+	}
+	if sl.step == 0 {
+		return nil, fmt.Errorf("invalid slice %q: step cannot be zero", s)
+	}
+	return sl, nil
+}
+
+// matchingBracket returns the position of the ']' matching the '[' at position at,
+// skipping over quoted strings and nested brackets
+func matchingBracket(p string, at int) (int, error) {
+	depth := 0
+	var quote byte
+	for i := at; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+			if depth == 0 {
+				if c != ']' {
+					return 0, fmt.Errorf("mismatched brackets at position %d", i)
+				}
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unterminated '[' at position %d", at)
+}
+
+// splitTopLevel splits s on sep, ignoring separators inside quotes
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	var quote byte
+	last := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == sep:
+			parts = append(parts, s[last:i])
+			last = i + 1
+		}
+	}
+	return append(parts, s[last:])
+}
+
+// nameSelector selects the child with the given key
+type nameSelector string
+
+func (s nameSelector) selectFrom(node any, out []any) []any {
+	if v, ok := helper.Field(node, string(s)); ok {
+		out = append(out, v)
+	}
+	return out
+}
+
+// wildcardSelector selects all children
+type wildcardSelector struct{}
+
+func (wildcardSelector) selectFrom(node any, out []any) []any {
+	return append(out, children(node)...)
+}
+
+// indexSelector selects a single list item
+type indexSelector int
+
+func (s indexSelector) selectFrom(node any, out []any) []any {
+	if v, ok := helper.Index(node, int(s)); ok {
+		out = append(out, v)
+	}
+	return out
+}
+
+// sliceSelector selects a range of list items
+type sliceSelector struct {
+	start, end *int
+	step       int
+}
+
+func (s sliceSelector) selectFrom(node any, out []any) []any {
+	a, l, err := helper.ListInfo(node)
+	if err != nil {
+		return out
+	}
+
+	bound := func(p *int, def int) int {
+		if p == nil {
+			return def
+		}
+		v := *p
+		if v < 0 {
+			v += l
+		}
+		return max(-1, min(v, l))
+	}
+
+	if s.step > 0 {
+		for i := max(bound(s.start, 0), 0); i < bound(s.end, l); i += s.step {
+			out = append(out, a.Index(i).Interface())
+		}
+	} else {
+		for i := min(bound(s.start, l-1), l-1); i > bound(s.end, -1); i += s.step {
+			out = append(out, a.Index(i).Interface())
+		}
+	}
+	return out
+}
+
+// unionSelector selects the combined results of several selectors
+type unionSelector []selector
+
+func (s unionSelector) selectFrom(node any, out []any) []any {
+	for _, sel := range s {
+		out = sel.selectFrom(node, out)
+	}
+	return out
+}
+
+// filterSelector selects the children for which the filter expression is true
+type filterSelector struct {
+	expr filterExpr
+}
+
+func (s filterSelector) selectFrom(node any, out []any) []any {
+	for _, c := range children(node) {
+		if truthy(s.expr.eval(c)) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// children returns the values of a map (ordered by key) or the items of a list
+func children(node any) []any {
+	if node == nil {
+		return nil
+	}
+
+	switch reflect.Indirect(reflect.ValueOf(node)).Kind() {
+	case reflect.Map:
+		m, err := helper.AsMap(node)
+		if err != nil {
+			return nil
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		res := make([]any, len(keys))
+		for i, k := range keys {
+			res[i] = m[k]
+		}
+		return res
+	case reflect.Slice, reflect.Array:
+		a, l, _ := helper.ListInfo(node)
+		res := make([]any, l)
+		for i := 0; i < l; i++ {
+			res[i] = a.Index(i).Interface()
+		}
+		return res
+	}
+	return nil
+}
+
+// descendants returns the node and everything below it, in document order
+func descendants(node any, out []any) []any {
+	out = append(out, node)
+	for _, c := range children(node) {
+		out = descendants(c, out)
+	}
+	return out
+}
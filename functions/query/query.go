@@ -0,0 +1,31 @@
+// Package query provides methods for looking up values within nested data structures in templates
+package query
+
+import (
+	"text/template"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// Functions that look up values in nested data
+func Functions() template.FuncMap {
+	return template.FuncMap{
+		"dig":   Dig,
+		"query": Query,
+	}
+}
+
+// Dig returns the value found by following the path (eg "a.b[0].c") into the data, or nil if any part of it is missing
+func Dig(path string, data any) (any, error) {
+	v, _, err := helper.LookupPath(path, data)
+	return v, err
+}
+
+// Query returns the list of values in the data matched by the given JSONPath expression
+func Query(path string, data any) ([]any, error) {
+	q, err := Compile(path)
+	if err != nil {
+		return nil, err
+	}
+	return q.Apply(data), nil
+}
@@ -0,0 +1,252 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testData returns a structure as it would be produced by ReadDataFile
+func testData(t *testing.T) map[string]any {
+	t.Helper()
+	d := make(map[string]any)
+	err := json.Unmarshal([]byte(`{
+		"store": {
+			"book": [
+				{"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+				{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+				{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99}
+			],
+			"bicycle": {"color": "red", "price": 19.95}
+		},
+		"dotted.key": "dots",
+		"empty": null
+	}`), &d)
+	require.NoError(t, err)
+	return d
+}
+
+// TestFunctions provides unit test coverage for Functions
+func TestFunctions(t *testing.T) {
+	fn := Functions()
+	assert.Len(t, fn, 2, "weakly ensuring functions haven't been added/removed without updating tests")
+}
+
+// TestDig provides unit test coverage for Dig()
+func TestDig(t *testing.T) {
+	d := testData(t)
+	tests := []helper.TestSet{
+		{
+			Name:     "whole document",
+			Template: `{{ dig "." .bicycle }}`,
+			Args:     helper.TestArgs{"bicycle": map[string]any{"color": "red"}},
+			Want:     "map[color:red]",
+		},
+		{
+			Name:     "nested key",
+			Template: `{{ dig "store.bicycle.color" . }}`,
+			Args:     d,
+			Want:     "red",
+		},
+		{
+			Name:     "list index",
+			Template: `{{ dig "store.book[1].author" . }}`,
+			Args:     d,
+			Want:     "Evelyn Waugh",
+		},
+		{
+			Name:     "negative index",
+			Template: `{{ dig "$.store.book[-1].title" . }}`,
+			Args:     d,
+			Want:     "Moby Dick",
+		},
+		{
+			Name:     "quoted key",
+			Template: `{{ dig "['dotted.key']" . }}`,
+			Args:     d,
+			Want:     "dots",
+		},
+		{
+			Name:     "typed result",
+			Template: `{{ dig "store.book[0].price" . | printf "%T" }}`,
+			Args:     d,
+			Want:     "float64",
+		},
+		{
+			Name:     "missing key",
+			Template: `{{ dig "store.car.color" . }}`,
+			Args:     d,
+			Want:     "<no value>",
+		},
+		{
+			Name:     "index out of range",
+			Template: `{{ dig "store.book[7].title" . }}`,
+			Args:     d,
+			Want:     "<no value>",
+		},
+		{
+			Name:     "index into a map",
+			Template: `{{ dig "store[0]" . }}`,
+			Args:     d,
+			Want:     "<no value>",
+		},
+		{
+			Name:     "struct field",
+			Template: `{{ dig "S.Name" . }}`,
+			Args: helper.TestArgs{
+				"S": struct{ Name string }{Name: "struct"},
+			},
+			Want: "struct",
+		},
+		{
+			Name:     "bad path",
+			Template: `{{ dig "store..book" . }}`,
+			Args:     d,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad index",
+			Template: `{{ dig "store.book[x]" . }}`,
+			Args:     d,
+			WantErr:  true,
+		},
+		{
+			Name:     "unterminated index",
+			Template: `{{ dig "store.book[0" . }}`,
+			Args:     d,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestQuery provides unit test coverage for Query()
+func TestQuery(t *testing.T) {
+	d := testData(t)
+	tests := []helper.TestSet{
+		{
+			Name:     "single key",
+			Template: `{{ query "$.store.bicycle.color" . }}`,
+			Args:     d,
+			Want:     "[red]",
+		},
+		{
+			Name:     "no match",
+			Template: `{{ query "$.store.car" . }}`,
+			Args:     d,
+			Want:     "[]",
+		},
+		{
+			Name:     "wildcard",
+			Template: `{{ query "$.store.book[*].author" . }}`,
+			Args:     d,
+			Want:     "[Nigel Rees Evelyn Waugh Herman Melville]",
+		},
+		{
+			Name:     "map wildcard",
+			Template: `{{ query "$.store.bicycle.*" . }}`,
+			Args:     d,
+			Want:     "[red 19.95]",
+		},
+		{
+			Name:     "recursive descent",
+			Template: `{{ query "$..price" . }}`,
+			Args:     d,
+			Want:     "[19.95 8.95 12.99 8.99]",
+		},
+		{
+			Name:     "recursive descent with index",
+			Template: `{{ query "$..book[0].title" . }}`,
+			Args:     d,
+			Want:     "[Sayings of the Century]",
+		},
+		{
+			Name:     "slice",
+			Template: `{{ query "store.book[0:2].price" . }}`,
+			Args:     d,
+			Want:     "[8.95 12.99]",
+		},
+		{
+			Name:     "reverse slice",
+			Template: `{{ query "store.book[::-1].price" . }}`,
+			Args:     d,
+			Want:     "[8.99 12.99 8.95]",
+		},
+		{
+			Name:     "union",
+			Template: `{{ query "store.book[0,-1].price" . }}`,
+			Args:     d,
+			Want:     "[8.95 8.99]",
+		},
+		{
+			Name:     "key union",
+			Template: `{{ query "store.bicycle['price','color']" . }}`,
+			Args:     d,
+			Want:     "[19.95 red]",
+		},
+		{
+			Name:     "filter comparison",
+			Template: `{{ query "$.store.book[?(@.price < 10)].title" . }}`,
+			Args:     d,
+			Want:     "[Sayings of the Century Moby Dick]",
+		},
+		{
+			Name:     "filter string equality",
+			Template: `{{ query "$.store.book[?(@.category == 'fiction' && @.price > 10)].author" . }}`,
+			Args:     d,
+			Want:     "[Evelyn Waugh]",
+		},
+		{
+			Name:     "filter existence",
+			Template: `{{ query "$.store.book[?(@.isbn)].title" . }}`,
+			Args:     d,
+			Want:     "[Moby Dick]",
+		},
+		{
+			Name:     "filter negation and grouping",
+			Template: `{{ query "$.store.book[?(!(@.isbn || @.category == 'reference'))].title" . }}`,
+			Args:     d,
+			Want:     "[Sword of Honour]",
+		},
+		{
+			Name:     "filter null",
+			Template: `{{ query "$[?(@ == null)]" . }}`,
+			Args:     d,
+			Want:     "[<nil>]",
+		},
+		{
+			Name:     "bad filter",
+			Template: `{{ query "$.store.book[?(@.price <)]" . }}`,
+			Args:     d,
+			WantErr:  true,
+		},
+		{
+			Name:     "filter without brackets",
+			Template: `{{ query "$.store.book[?@.price]" . }}`,
+			Args:     d,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad slice",
+			Template: `{{ query "$.store.book[0:1:0]" . }}`,
+			Args:     d,
+			WantErr:  true,
+		},
+		{
+			Name:     "unterminated bracket",
+			Template: `{{ query "$.store.book[0" . }}`,
+			Args:     d,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
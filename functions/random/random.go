@@ -0,0 +1,172 @@
+// Package random provides functions for generating random values in templates
+package random
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"text/template"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+const (
+	letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digits  = "0123456789"
+)
+
+// Functions that generate random values.
+//
+// Unless prefixed with "crypto", they use helper.Random and so give reproducible output when it has been seeded
+func Functions() template.FuncMap {
+	return template.FuncMap{
+		"randInt":          RandInt,
+		"randFloat":        RandFloat,
+		"randChoice":       RandChoice,
+		"shuffle":          Shuffle,
+		"randString":       RandString,
+		"randAlnum":        RandAlnum,
+		"cryptoRandInt":    CryptoRandInt,
+		"cryptoRandString": CryptoRandString,
+		"cryptoRandAlnum":  CryptoRandAlnum,
+	}
+}
+
+// intn returns a random number in the range [0,n)
+type intn func(n int64) (int64, error)
+
+// seededIntn uses the seedable helper.Random source
+func seededIntn(n int64) (int64, error) {
+	return helper.Random.Int63n(n), nil
+}
+
+// cryptoIntn uses the cryptographically secure random number generator
+func cryptoIntn(n int64) (int64, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(n))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read random number: %w", err)
+	}
+	return i.Int64(), nil
+}
+
+// RandInt returns a random integer that is at least min and less than max
+func RandInt(min, max any) (int64, error) {
+	return randInt(min, max, seededIntn)
+}
+
+// CryptoRandInt returns a cryptographically secure random integer that is at least min and less than max
+func CryptoRandInt(min, max any) (int64, error) {
+	return randInt(min, max, cryptoIntn)
+}
+
+func randInt(min, max any, fn intn) (int64, error) {
+	lo, err := helper.ToInt(min)
+	if err != nil {
+		return 0, err
+	}
+	hi, err := helper.ToInt(max)
+	if err != nil {
+		return 0, err
+	}
+	if hi <= lo {
+		return 0, fmt.Errorf("max (%d) must be greater than min (%d)", hi, lo)
+	}
+
+	span := hi - lo
+	if span < 0 {
+		return 0, fmt.Errorf("range from %d to %d is too large", lo, hi)
+	}
+
+	i, err := fn(span)
+	if err != nil {
+		return 0, err
+	}
+	return lo + i, nil
+}
+
+// RandFloat returns a random number that is at least min and less than max
+func RandFloat(min, max any) (float64, error) {
+	lo, err := helper.ToFloat(min)
+	if err != nil {
+		return 0, err
+	}
+	hi, err := helper.ToFloat(max)
+	if err != nil {
+		return 0, err
+	}
+	if hi <= lo {
+		return 0, fmt.Errorf("max (%g) must be greater than min (%g)", hi, lo)
+	}
+	return lo + helper.Random.Float64()*(hi-lo), nil
+}
+
+// RandChoice returns a randomly chosen element of a list
+func RandChoice(list any) (any, error) {
+	a, l, err := helper.ListInfo(list)
+	if err != nil {
+		return nil, err
+	}
+	if l == 0 {
+		return nil, errors.New("can't choose from an empty list")
+	}
+	return a.Index(helper.Random.Intn(l)).Interface(), nil
+}
+
+// Shuffle returns a copy of a list with the elements in a random order
+func Shuffle(list any) ([]any, error) {
+	a, l, err := helper.ListInfo(list)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]any, l)
+	for i := range res {
+		res[i] = a.Index(i).Interface()
+	}
+	helper.Random.Shuffle(l, func(i, j int) {
+		res[i], res[j] = res[j], res[i]
+	})
+	return res, nil
+}
+
+// RandString returns a string of random letters of the given length
+func RandString(length any) (string, error) {
+	return randString(length, letters, seededIntn)
+}
+
+// RandAlnum returns a string of random letters and digits of the given length
+func RandAlnum(length any) (string, error) {
+	return randString(length, letters+digits, seededIntn)
+}
+
+// CryptoRandString returns a string of cryptographically secure random letters of the given length
+func CryptoRandString(length any) (string, error) {
+	return randString(length, letters, cryptoIntn)
+}
+
+// CryptoRandAlnum returns a string of cryptographically secure random letters and digits of the given length,
+// suitable for passwords and other secrets
+func CryptoRandAlnum(length any) (string, error) {
+	return randString(length, letters+digits, cryptoIntn)
+}
+
+func randString(length any, chars string, fn intn) (string, error) {
+	l, err := helper.ToInt(length)
+	if err != nil {
+		return "", err
+	}
+	if l < 0 {
+		return "", fmt.Errorf("length must not be negative, got %d", l)
+	}
+
+	b := make([]byte, l)
+	for i := range b {
+		c, errC := fn(int64(len(chars)))
+		if errC != nil {
+			return "", errC
+		}
+		b[i] = chars[c]
+	}
+	return string(b), nil
+}
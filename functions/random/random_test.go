@@ -0,0 +1,249 @@
+package random
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSeed is used to get reproducible output from the seeded functions
+const testSeed = 42
+
+// runSeeded runs each test with the random source freshly seeded.
+// Unlike helper.TemplateTest, the tests are run sequentially as they share the source
+func runSeeded(t *testing.T, tests []helper.TestSet) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			helper.SeedRandom(testSeed)
+
+			var got bytes.Buffer
+			tpl := helper.NewTemplate(t, tt.Template, Functions())
+			err := tpl.ExecuteTemplate(&got, helper.TestTemplateName, tt.Args)
+			if tt.WantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.Want, got.String())
+		})
+	}
+}
+
+// TestFunctions provides unit test coverage for Functions
+func TestFunctions(t *testing.T) {
+	fn := Functions()
+	assert.Len(t, fn, 9, "weakly ensuring functions haven't been added/removed without updating tests")
+}
+
+// TestRandInt provides unit test coverage for RandInt()
+func TestRandInt(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "dice",
+			Template: `{{ randInt 1 7 }}`,
+			Want:     "2",
+		},
+		{
+			Name:     "negative range",
+			Template: `{{ randInt -10 10 }}`,
+			Want:     "5",
+		},
+		{
+			Name:     "single value",
+			Template: `{{ randInt 3 4 }}`,
+			Want:     "3",
+		},
+		{
+			Name:     "empty range",
+			Template: `{{ randInt 3 3 }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "range too large",
+			Template: `{{ randInt -9223372036854775808 9223372036854775807 }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad min",
+			Template: `{{ randInt "a" 3 }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad max",
+			Template: `{{ randInt 1 "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	runSeeded(t, tests)
+}
+
+// TestRandFloat provides unit test coverage for RandFloat()
+func TestRandFloat(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "unit",
+			Template: `{{ randFloat 0 1 }}`,
+			Want:     "0.3730283610466326",
+		},
+		{
+			Name:     "negative range",
+			Template: `{{ randFloat -5 5 }}`,
+			Want:     "-1.2697163895336736",
+		},
+		{
+			Name:     "empty range",
+			Template: `{{ randFloat 1 1 }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad min",
+			Template: `{{ randFloat "a" 3 }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad max",
+			Template: `{{ randFloat 1 "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	runSeeded(t, tests)
+}
+
+// TestRandChoice provides unit test coverage for RandChoice()
+func TestRandChoice(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "simple",
+			Template: `{{ randChoice .list }}`,
+			Args:     helper.TestArgs{"list": []string{"a", "b", "c"}},
+			Want:     "c",
+		},
+		{
+			Name:     "empty list",
+			Template: `{{ randChoice .list }}`,
+			Args:     helper.TestArgs{"list": []string{}},
+			WantErr:  true,
+		},
+		{
+			Name:     "not a list",
+			Template: `{{ randChoice 1 }}`,
+			WantErr:  true,
+		},
+	}
+
+	runSeeded(t, tests)
+}
+
+// TestShuffle provides unit test coverage for Shuffle()
+func TestShuffle(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "simple",
+			Template: `{{ shuffle .list }}`,
+			Args:     helper.TestArgs{"list": []int{1, 2, 3, 4, 5}},
+			Want:     "[3 4 5 1 2]",
+		},
+		{
+			Name:     "original unchanged",
+			Template: `{{ $s := shuffle .list }}{{ .list }}`,
+			Args:     helper.TestArgs{"list": []int{1, 2, 3, 4, 5}},
+			Want:     "[1 2 3 4 5]",
+		},
+		{
+			Name:     "empty list",
+			Template: `{{ shuffle .list }}`,
+			Args:     helper.TestArgs{"list": []int{}},
+			Want:     "[]",
+		},
+		{
+			Name:     "not a list",
+			Template: `{{ shuffle 1 }}`,
+			WantErr:  true,
+		},
+	}
+
+	runSeeded(t, tests)
+}
+
+// TestRandString provides unit test coverage for RandString() and RandAlnum()
+func TestRandString(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "letters",
+			Template: `{{ randString 12 }}`,
+			Want:     "NxeLvzNqaiIz",
+		},
+		{
+			Name:     "alphanumeric",
+			Template: `{{ randAlnum 12 }}`,
+			Want:     "zR0Rz7pYIACJ",
+		},
+		{
+			Name:     "empty",
+			Template: `{{ randString 0 }}`,
+			Want:     "",
+		},
+		{
+			Name:     "negative length",
+			Template: `{{ randAlnum -1 }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "bad length",
+			Template: `{{ randString "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	runSeeded(t, tests)
+}
+
+// TestSeeding ensures that the seeded functions are reproducible
+func TestSeeding(t *testing.T) {
+	helper.SeedRandom(testSeed)
+	a, err := RandAlnum(32)
+	require.NoError(t, err)
+
+	helper.SeedRandom(testSeed)
+	b, err := RandAlnum(32)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := RandAlnum(32)
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c)
+}
+
+// TestCrypto provides unit test coverage for CryptoRandInt(), CryptoRandString() and CryptoRandAlnum()
+func TestCrypto(t *testing.T) {
+	helper.SeedRandom(testSeed)
+	s, err := CryptoRandString(32)
+	require.NoError(t, err)
+	assert.Regexp(t, `^[a-zA-Z]{32}$`, s)
+
+	helper.SeedRandom(testSeed)
+	s2, err := CryptoRandString(32)
+	require.NoError(t, err)
+	assert.NotEqual(t, s, s2, "crypto functions should not be affected by seeding")
+
+	s, err = CryptoRandAlnum(20)
+	require.NoError(t, err)
+	assert.Regexp(t, `^[a-zA-Z0-9]{20}$`, s)
+
+	for i := 0; i < 100; i++ {
+		n, errN := CryptoRandInt(-2, 3)
+		require.NoError(t, errN)
+		assert.True(t, n >= -2 && n < 3, "%d out of range", n)
+	}
+
+	_, err = CryptoRandInt(3, 1)
+	assert.Error(t, err)
+	_, err = CryptoRandAlnum(-1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+package strings
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// Replace replaces all occurrences of old in the string with repl
+func Replace(old string, repl string, s string) string {
+	return strings.ReplaceAll(s, old, repl)
+}
+
+// ReplaceN replaces the first n occurrences of old in the string with repl, or all of them if n is negative
+func ReplaceN(old string, repl string, n int, s string) string {
+	return strings.Replace(s, old, repl, n)
+}
+
+// Substr returns the characters of the string from start up to (but not including) end.
+// Negative positions count back from the end of the string, and positions outside of it are clamped to it
+func Substr(start int, end int, s string) string {
+	r := []rune(s)
+	from, to := position(start, len(r)), position(end, len(r))
+	if from >= to {
+		return ""
+	}
+	return string(r[from:to])
+}
+
+// position converts a possibly negative position to an index within a string of length l
+func position(p int, l int) int {
+	if p < 0 {
+		p += l
+	}
+	return max(0, min(p, l))
+}
+
+// Truncate shortens the string to at most n characters, ending it with the ellipsis if it was cut
+func Truncate(n int, ellipsis string, s string) (string, error) {
+	if n < 0 {
+		return "", errors.New("cannot truncate to a negative length")
+	}
+	r := []rune(s)
+	if len(r) <= n {
+		return s, nil
+	}
+
+	e := []rune(ellipsis)
+	if len(e) >= n {
+		return string(e[:n]), nil
+	}
+	return string(r[:n-len(e)]) + ellipsis, nil
+}
+
+// EllipsisMiddle shortens the string to at most n characters by replacing the middle of it with the ellipsis,
+// which is useful for long paths and identifiers where both ends are significant
+func EllipsisMiddle(n int, ellipsis string, s string) (string, error) {
+	if n < 0 {
+		return "", errors.New("cannot shorten to a negative length")
+	}
+	r := []rune(s)
+	if len(r) <= n {
+		return s, nil
+	}
+
+	e := []rune(ellipsis)
+	if len(e) >= n {
+		return string(e[:n]), nil
+	}
+	keep := n - len(e)
+	head := (keep + 1) / 2
+	tail := keep - head
+	return string(r[:head]) + ellipsis + string(r[len(r)-tail:]), nil
+}
+
+// Count returns the number of non-overlapping occurrences of substr in the string
+func Count(substr string, s string) int {
+	return strings.Count(s, substr)
+}
+
+// IndexOf returns the (character, not byte) position of the first occurrence of substr in the string,
+// or -1 if it isn't found
+func IndexOf(substr string, s string) int {
+	i := strings.Index(s, substr)
+	if i < 0 {
+		return -1
+	}
+	return utf8.RuneCountInString(s[:i])
+}
+
+// RepeatSep returns count copies of the string, separated by sep
+func RepeatSep(count int, sep string, s string) string {
+	if count <= 0 {
+		return ""
+	}
+	return strings.Repeat(s+sep, count-1) + s
+}
@@ -0,0 +1,192 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// TestReplace provides unit test coverage for Replace() and ReplaceN()
+func TestReplace(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "all",
+			Template: `{{ replace "o" "0" "foo boo" }}`,
+			Want:     "f00 b00",
+		},
+		{
+			Name:     "unicode",
+			Template: `{{ replace "é" "e" "café résumé" }}`,
+			Want:     "cafe resume",
+		},
+		{
+			Name:     "first n",
+			Template: `{{ replaceN "o" "0" 3 "foo boo" }}`,
+			Want:     "f00 b0o",
+		},
+		{
+			Name:     "negative n",
+			Template: `{{ replaceN "o" "0" -1 "foo boo" }}`,
+			Want:     "f00 b00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestSubstr provides unit test coverage for Substr()
+func TestSubstr(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "middle",
+			Template: `{{ substr 1 4 "abcdef" }}`,
+			Want:     "bcd",
+		},
+		{
+			Name:     "multibyte",
+			Template: `{{ substr 2 5 "日本語のテキスト" }}`,
+			Want:     "語のテ",
+		},
+		{
+			Name:     "negative positions",
+			Template: `{{ substr -4 -1 "résumé" }}`,
+			Want:     "sum",
+		},
+		{
+			Name:     "clamped",
+			Template: `{{ substr -100 100 "résumé" }}`,
+			Want:     "résumé",
+		},
+		{
+			Name:     "start after end",
+			Template: `{{ substr 4 2 "abcdef" }}`,
+			Want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestTruncate provides unit test coverage for Truncate() and EllipsisMiddle()
+func TestTruncate(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "short enough",
+			Template: `{{ truncate 10 "…" "hello" }}`,
+			Want:     "hello",
+		},
+		{
+			Name:     "truncated",
+			Template: `{{ truncate 8 "…" "hello wonderful world" }}`,
+			Want:     "hello w…",
+		},
+		{
+			Name:     "multibyte",
+			Template: `{{ truncate 4 "..." "日本語のテキスト" }}`,
+			Want:     "日...",
+		},
+		{
+			Name:     "no ellipsis",
+			Template: `{{ truncate 3 "" "résumé" }}`,
+			Want:     "rés",
+		},
+		{
+			Name:     "longer ellipsis",
+			Template: `{{ truncate 2 "..." "résumé" }}`,
+			Want:     "..",
+		},
+		{
+			Name:     "negative",
+			Template: `{{ truncate -1 "" "résumé" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "middle",
+			Template: `{{ ellipsisMiddle 11 "…" "/usr/local/share/tplr/templates" }}`,
+			Want:     "/usr/…lates",
+		},
+		{
+			Name:     "middle multibyte",
+			Template: `{{ ellipsisMiddle 5 "~" "日本語のテキスト" }}`,
+			Want:     "日本~スト",
+		},
+		{
+			Name:     "middle short enough",
+			Template: `{{ ellipsisMiddle 6 "…" "résumé" }}`,
+			Want:     "résumé",
+		},
+		{
+			Name:     "middle longer ellipsis",
+			Template: `{{ ellipsisMiddle 1 "..." "résumé" }}`,
+			Want:     ".",
+		},
+		{
+			Name:     "middle negative",
+			Template: `{{ ellipsisMiddle -1 "" "résumé" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestCount provides unit test coverage for Count() and IndexOf()
+func TestCount(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "count",
+			Template: `{{ count "an" "banana" }}`,
+			Want:     "2",
+		},
+		{
+			Name:     "count multibyte",
+			Template: `{{ count "é" "résumé" }}`,
+			Want:     "2",
+		},
+		{
+			Name:     "index",
+			Template: `{{ indexOf "sum" "résumé" }}`,
+			Want:     "2",
+		},
+		{
+			Name:     "index not found",
+			Template: `{{ indexOf "x" "résumé" }}`,
+			Want:     "-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestRepeatSep provides unit test coverage for RepeatSep()
+func TestRepeatSep(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "placeholders",
+			Template: `({{ repeatSep 3 ", " "?" }})`,
+			Want:     "(?, ?, ?)",
+		},
+		{
+			Name:     "once",
+			Template: `{{ repeatSep 1 ", " "?" }}`,
+			Want:     "?",
+		},
+		{
+			Name:     "none",
+			Template: `{{ repeatSep 0 ", " "?" }}`,
+			Want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -0,0 +1,107 @@
+package strings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// shellSafe matches strings that don't need quoting in a POSIX shell
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+var (
+	singleQuoteEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	xmlEscaper         = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;")
+)
+
+// QuoteSingleEscaped adds single quotes around the given string, escaping any single quotes and backslashes within it
+func QuoteSingleEscaped(item any) string {
+	return "'" + singleQuoteEscaper.Replace(fmt.Sprint(item)) + "'"
+}
+
+// QuoteBackEscaped returns the given string as a Go raw (back-quoted) string literal.
+// As raw strings can't contain back-quotes (or carriage returns), they are added as separate
+// double-quoted strings, eg "a`b" becomes `a` + "`" + `b`
+func QuoteBackEscaped(item any) string {
+	s := fmt.Sprint(item)
+	if s == "" {
+		return "``"
+	}
+
+	var parts []string
+	for s != "" {
+		i := strings.IndexAny(s, "`\r")
+		if i < 0 {
+			i = len(s)
+		}
+		if i > 0 {
+			parts = append(parts, "`"+s[:i]+"`")
+		}
+		if i < len(s) {
+			parts = append(parts, strconv.Quote(s[i:i+1]))
+			i++
+		}
+		s = s[i:]
+	}
+	return strings.Join(parts, " + ")
+}
+
+// ShellQuote quotes the given string (if required) so that a POSIX shell treats it as a single word
+func ShellQuote(item any) string {
+	s := fmt.Sprint(item)
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// SQLString quotes the given string as an SQL string literal, doubling any single quotes within it
+func SQLString(item any) string {
+	return "'" + strings.ReplaceAll(fmt.Sprint(item), "'", "''") + "'"
+}
+
+// RegexQuote escapes all regular expression metacharacters in the given string, so that it matches literally
+func RegexQuote(item any) string {
+	return regexp.QuoteMeta(fmt.Sprint(item))
+}
+
+// URLQuery escapes the given string for use as a URL query parameter
+func URLQuery(item any) string {
+	return url.QueryEscape(fmt.Sprint(item))
+}
+
+// URLPath escapes the given string for use as a segment of a URL path
+func URLPath(item any) string {
+	return url.PathEscape(fmt.Sprint(item))
+}
+
+// XMLEscape replaces the characters that are special in XML text and attributes with entities
+func XMLEscape(item any) string {
+	return xmlEscaper.Replace(fmt.Sprint(item))
+}
+
+// HTMLEscape replaces the characters that are special in HTML with entities
+func HTMLEscape(item any) string {
+	return html.EscapeString(fmt.Sprint(item))
+}
+
+// JSONString returns the given string as a quoted JSON string
+func JSONString(item any) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(fmt.Sprint(item)); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// GoString returns the given string as a quoted Go string literal
+func GoString(item any) string {
+	return strconv.Quote(fmt.Sprint(item))
+}
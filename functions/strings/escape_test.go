@@ -0,0 +1,192 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// TestQuoteEscaped provides unit test coverage for QuoteSingleEscaped() and QuoteBackEscaped()
+func TestQuoteEscaped(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "single",
+			Template: `{{ qEsc .S }}`,
+			Args:     helper.TestArgs{"S": `it's a \ test`},
+			Want:     `'it\'s a \\ test'`,
+		},
+		{
+			Name:     "double",
+			Template: `{{ qqEsc .S }}`,
+			Args:     helper.TestArgs{"S": "say \"hi\"\n\\"},
+			Want:     `"say \"hi\"\n\\"`,
+		},
+		{
+			Name:     "back",
+			Template: `{{ qbEsc .S }}`,
+			Args:     helper.TestArgs{"S": "a\\b`c"},
+			Want:     "`a\\b` + \"`\" + `c`",
+		},
+		{
+			Name:     "back with leading and trailing quotes",
+			Template: `{{ qbEsc .S }}`,
+			Args:     helper.TestArgs{"S": "`ls`"},
+			Want:     "\"`\" + `ls` + \"`\"",
+		},
+		{
+			Name:     "back with carriage return",
+			Template: `{{ qbEsc .S }}`,
+			Args:     helper.TestArgs{"S": "a\r\nb"},
+			Want:     "`a` + \"\\r\" + `\nb`",
+		},
+		{
+			Name:     "back empty",
+			Template: `{{ qbEsc "" }}`,
+			Want:     "``",
+		},
+		{
+			Name:     "not a string",
+			Template: `{{ qqEsc 12 }}`,
+			Want:     `"12"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestShellQuote provides unit test coverage for ShellQuote()
+func TestShellQuote(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "safe",
+			Template: `{{ shellQuote "/usr/local/bin:x=1,y@2%" }}`,
+			Want:     `/usr/local/bin:x=1,y@2%`,
+		},
+		{
+			Name:     "empty",
+			Template: `{{ shellQuote "" }}`,
+			Want:     `''`,
+		},
+		{
+			Name:     "special characters",
+			Template: `{{ shellQuote .S }}`,
+			Args:     helper.TestArgs{"S": "$HOME; rm -rf `pwd` \"*\""},
+			Want:     "'$HOME; rm -rf `pwd` \"*\"'",
+		},
+		{
+			Name:     "single quotes",
+			Template: `{{ shellQuote "it's" }}`,
+			Want:     `'it'\''s'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestSQLString provides unit test coverage for SQLString()
+func TestSQLString(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "plain",
+			Template: `{{ sqlString "abc" }}`,
+			Want:     `'abc'`,
+		},
+		{
+			Name:     "quotes",
+			Template: `{{ sqlString "O'Brien'; DROP TABLE users; --" }}`,
+			Want:     `'O''Brien''; DROP TABLE users; --'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestRegexQuote provides unit test coverage for RegexQuote()
+func TestRegexQuote(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "metacharacters",
+			Template: `{{ regexQuote "1.5*[a-z]+(x|y)?^$\\{2}" }}`,
+			Want:     `1\.5\*\[a-z\]\+\(x\|y\)\?\^\$\\\{2\}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestURLEscape provides unit test coverage for URLQuery() and URLPath()
+func TestURLEscape(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "query",
+			Template: `?q={{ urlQuery "a b&c=d/é" }}`,
+			Want:     `?q=a+b%26c%3Dd%2F%C3%A9`,
+		},
+		{
+			Name:     "path",
+			Template: `/files/{{ urlPath "a b&c/d?" }}`,
+			Want:     `/files/a%20b&c%2Fd%3F`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestMarkupEscape provides unit test coverage for XMLEscape() and HTMLEscape()
+func TestMarkupEscape(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "xml",
+			Template: `{{ xmlEscape .S }}`,
+			Args:     helper.TestArgs{"S": `<a href="x">Tom & Jerry's</a>`},
+			Want:     `&lt;a href=&quot;x&quot;&gt;Tom &amp; Jerry&apos;s&lt;/a&gt;`,
+		},
+		{
+			Name:     "html",
+			Template: `{{ htmlEscape .S }}`,
+			Args:     helper.TestArgs{"S": `<a href="x">Tom & Jerry's</a>`},
+			Want:     `&lt;a href=&#34;x&#34;&gt;Tom &amp; Jerry&#39;s&lt;/a&gt;`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestCodeString provides unit test coverage for JSONString() and GoString()
+func TestCodeString(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "json",
+			Template: `{{ jsonString .S }}`,
+			Args:     helper.TestArgs{"S": "<\"tab\"\t\\ é\u0001>"},
+			Want:     `"<\"tab\"\t\\ é\u0001>"`,
+		},
+		{
+			Name:     "json of a number",
+			Template: `{{ jsonString 1.5 }}`,
+			Want:     `"1.5"`,
+		},
+		{
+			Name:     "go",
+			Template: `{{ goString .S }}`,
+			Args:     helper.TestArgs{"S": "\"tab\"\t\\ é\u0001"},
+			Want:     `"\"tab\"\t\\ é\x01"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -0,0 +1,248 @@
+package strings
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/mantidtech/wordcase"
+)
+
+// irregularPlurals are the (English) words that don't follow the rules for making plurals,
+// or singular words ending in "s" that would otherwise be taken to be plurals
+var irregularPlurals = map[string]string{
+	"person":     "people",
+	"man":        "men",
+	"woman":      "women",
+	"child":      "children",
+	"ox":         "oxen",
+	"foot":       "feet",
+	"tooth":      "teeth",
+	"goose":      "geese",
+	"mouse":      "mice",
+	"louse":      "lice",
+	"die":        "dice",
+	"leaf":       "leaves",
+	"loaf":       "loaves",
+	"life":       "lives",
+	"wife":       "wives",
+	"knife":      "knives",
+	"half":       "halves",
+	"calf":       "calves",
+	"elf":        "elves",
+	"self":       "selves",
+	"shelf":      "shelves",
+	"thief":      "thieves",
+	"wolf":       "wolves",
+	"hero":       "heroes",
+	"echo":       "echoes",
+	"veto":       "vetoes",
+	"potato":     "potatoes",
+	"tomato":     "tomatoes",
+	"quiz":       "quizzes",
+	"bus":        "buses",
+	"cactus":     "cacti",
+	"fungus":     "fungi",
+	"nucleus":    "nuclei",
+	"radius":     "radii",
+	"stimulus":   "stimuli",
+	"syllabus":   "syllabi",
+	"criterion":  "criteria",
+	"phenomenon": "phenomena",
+	"medium":     "media",
+	"index":      "indices",
+	"matrix":     "matrices",
+	"vertex":     "vertices",
+	"appendix":   "appendices",
+	"crisis":     "crises",
+	"thesis":     "theses",
+	"basis":      "bases",
+	"axis":       "axes",
+	"movie":      "movies",
+	"cookie":     "cookies",
+	"zombie":     "zombies",
+	"gas":        "gases",
+	"lens":       "lenses",
+	"bias":       "biases",
+	"alias":      "aliases",
+	"atlas":      "atlases",
+	"canvas":     "canvases",
+}
+
+// irregularSingulars are the reverse of irregularPlurals
+var irregularSingulars = func() map[string]string {
+	m := make(map[string]string, len(irregularPlurals))
+	for s, p := range irregularPlurals {
+		m[p] = s
+	}
+	m["bases"] = "base" // more often than basis
+	return m
+}()
+
+// uncountable words are the same in the singular and plural
+var uncountable = map[string]bool{
+	"sheep": true, "fish": true, "deer": true, "moose": true, "series": true, "species": true, "aircraft": true,
+	"information": true, "equipment": true, "news": true, "rice": true, "money": true, "software": true,
+	"hardware": true, "data": true, "metadata": true, "feedback": true, "music": true, "traffic": true, "chaos": true,
+}
+
+// lastWord splits the text before its last word, so that only the last word of a phrase is inflected.
+// The last word is the run of letters at the end, so it's empty if the text ends with anything else, eg a digit
+func lastWord(s string) (string, string) {
+	i := strings.LastIndexFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return s[:i+1], s[i+1:]
+}
+
+// matchCase returns the inflected word with the same case as the original (all uppercase, or a leading capital)
+func matchCase(orig, word string) string {
+	switch {
+	case len(orig) > 1 && orig == strings.ToUpper(orig):
+		return strings.ToUpper(word)
+	case orig != "" && unicode.IsUpper([]rune(orig)[0]):
+		return UppercaseFirst(word)
+	}
+	return word
+}
+
+// isVowel returns true for the vowels, when considering how to inflect a word
+func isVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
+
+// plural returns the plural of a single (lowercase) word
+func plural(w string) string {
+	if p, ok := irregularPlurals[w]; ok {
+		return p
+	}
+	if uncountable[w] || w == "" {
+		return w
+	}
+
+	switch n := len(w); {
+	case strings.HasSuffix(w, "sis"):
+		return w[:n-2] + "es"
+	case strings.HasSuffix(w, "s"), strings.HasSuffix(w, "x"), strings.HasSuffix(w, "z"),
+		strings.HasSuffix(w, "ch"), strings.HasSuffix(w, "sh"):
+		return w + "es"
+	case n > 1 && w[n-1] == 'y' && !isVowel(w[n-2]):
+		return w[:n-1] + "ies"
+	}
+	return w + "s"
+}
+
+// singular returns the singular of a single (lowercase) word
+func singular(w string) string {
+	if s, ok := irregularSingulars[w]; ok {
+		return s
+	}
+	if _, ok := irregularPlurals[w]; ok || uncountable[w] { // already singular
+		return w
+	}
+
+	switch n := len(w); {
+	case strings.HasSuffix(w, "ss"), strings.HasSuffix(w, "us"), strings.HasSuffix(w, "is"):
+		return w
+	case strings.HasSuffix(w, "yses"):
+		return w[:n-2] + "is"
+	case n > 5 && strings.HasSuffix(w, "uses") && strings.IndexByte("tnpr", w[n-5]) >= 0: // eg statuses, bonuses
+		return w[:n-2]
+	case n > 4 && strings.HasSuffix(w, "ies"):
+		return w[:n-3] + "y"
+	case strings.HasSuffix(w, "sses"), strings.HasSuffix(w, "xes"), strings.HasSuffix(w, "zzes"),
+		strings.HasSuffix(w, "ches"), strings.HasSuffix(w, "shes"):
+		return w[:n-2]
+	case strings.HasSuffix(w, "s"):
+		return w[:n-1]
+	}
+	return w
+}
+
+// Pluralize returns the (English) word in the plural, unless the count is 1.
+// Only the last word of a phrase is changed, and phrases ending with anything but a letter (eg "item2") are left as they are
+func Pluralize(count any, word string) (string, error) {
+	c, err := helper.ToFloat(count)
+	if err != nil {
+		return "", fmt.Errorf("pluralize count should be a number: %w", err)
+	}
+	if c == 1 || c == -1 {
+		return word, nil
+	}
+	pre, w := lastWord(word)
+	return pre + matchCase(w, plural(strings.ToLower(w))), nil
+}
+
+// Singularize returns the (English) word in the singular.
+// Only the last word of a phrase is changed, and phrases ending with anything but a letter are left as they are
+func Singularize(word string) string {
+	pre, w := lastWord(word)
+	return pre + matchCase(w, singular(strings.ToLower(w)))
+}
+
+// ToSentence joins the items of the list into a sentence, eg "a, b and c"
+func ToSentence(list any) (string, error) {
+	return ToSentenceWith(nil, list)
+}
+
+// ToSentenceWith joins the items of the list into a sentence, eg "a, b and c".
+// The options (a dictionary) are:
+//
+//	conjunction - the word before the last item (default "and")
+//	separator   - placed between the other items (default ", ")
+//	oxford      - whether to also add the separator before the conjunction with three or more items (default false)
+func ToSentenceWith(opts any, list any) (string, error) {
+	o, err := helper.NewOptions(opts, "conjunction", "separator", "oxford")
+	if err != nil {
+		return "", err
+	}
+	conj, err := o.String("conjunction", "and")
+	if err != nil {
+		return "", err
+	}
+	sep, err := o.String("separator", ", ")
+	if err != nil {
+		return "", err
+	}
+	oxford, err := o.Bool("oxford", false)
+	if err != nil {
+		return "", err
+	}
+
+	l, n, err := helper.ListInfo(list)
+	if err != nil {
+		return "", err
+	}
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprint(l.Index(i).Interface())
+	}
+
+	switch n {
+	case 0:
+		return "", nil
+	case 1:
+		return items[0], nil
+	case 2:
+		return items[0] + " " + conj + " " + items[1], nil
+	}
+	last := " " + conj + " "
+	if oxford {
+		last = strings.TrimRight(sep, " ") + last
+	}
+	return strings.Join(items[:n-1], sep) + last + items[n-1], nil
+}
+
+// humanizer splits text into words, as for the other case functions, and joins them as a sentence
+var humanizer = wordcase.NewPipeline().
+	TokenizeUsing(wordcase.LookAroundCategorizer, wordcase.NotLetterOrDigit, true).
+	TokenizeUsing(wordcase.LookAroundCategorizer, wordcase.NotLowerOrDigit, false).
+	WithAllFormatter(strings.ToLower).
+	WithFormatter(wordcase.UppercaseFirst, wordcase.ToFirst).
+	JoinWith(" ")
+
+// Humanize converts an identifier into a phrase starting with a capital, eg "user_id" to "User id"
+func Humanize(s string) string {
+	return humanizer(s)
+}
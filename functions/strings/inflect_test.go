@@ -0,0 +1,293 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPluralize provides unit test coverage for Pluralize()
+func TestPluralize(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "one",
+			Template: `{{ pluralize 1 "item" }}`,
+			Want:     "item",
+		},
+		{
+			Name:     "none",
+			Template: `{{ pluralize 0 "item" }}`,
+			Want:     "items",
+		},
+		{
+			Name:     "many",
+			Template: `{{ .N }} {{ pluralize .N "item" }}`,
+			Args:     helper.TestArgs{"N": 3},
+			Want:     "3 items",
+		},
+		{
+			Name:     "fraction",
+			Template: `{{ pluralize 1.5 "litre" }}`,
+			Want:     "litres",
+		},
+		{
+			Name:     "string count",
+			Template: `{{ pluralize "1" "item" }}`,
+			Want:     "item",
+		},
+		{
+			Name:     "phrase",
+			Template: `{{ pluralize 2 "user account" }}`,
+			Want:     "user accounts",
+		},
+		{
+			Name:     "irregular, keeping case",
+			Template: `{{ pluralize 2 "Person" }}`,
+			Want:     "People",
+		},
+		{
+			Name:     "uppercase",
+			Template: `{{ pluralize 2 "BOX" }}`,
+			Want:     "BOXES",
+		},
+		{
+			Name:     "ending with a digit",
+			Template: `{{ pluralize 2 "my item2" }}`,
+			Want:     "my item2",
+		},
+		{
+			Name:     "not a number",
+			Template: `{{ pluralize "some" "item" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestInflection provides unit test coverage for plural() and singular()
+func TestInflection(t *testing.T) {
+	words := map[string]string{
+		"item":     "items",
+		"box":      "boxes",
+		"class":    "classes",
+		"church":   "churches",
+		"wish":     "wishes",
+		"city":     "cities",
+		"day":      "days",
+		"key":      "keys",
+		"analysis": "analyses",
+		"crisis":   "crises",
+		"leaf":     "leaves",
+		"roof":     "roofs",
+		"child":    "children",
+		"mouse":    "mice",
+		"index":    "indices",
+		"potato":   "potatoes",
+		"photo":    "photos",
+		"shoe":     "shoes",
+		"house":    "houses",
+		"horse":    "horses",
+		"tie":      "ties",
+		"movie":    "movies",
+		"status":   "statuses",
+		"virus":    "viruses",
+		"bonus":    "bonuses",
+		"abuse":    "abuses",
+		"ruse":     "ruses",
+		"bus":      "buses",
+		"quiz":     "quizzes",
+		"sheep":    "sheep",
+		"news":     "news",
+		"gas":      "gases",
+		"lens":     "lenses",
+		"bias":     "biases",
+		"alias":    "aliases",
+		"canvas":   "canvases",
+		"chaos":    "chaos",
+	}
+	for s, p := range words {
+		assert.Equal(t, p, plural(s), "plural of %s", s)
+		assert.Equal(t, s, singular(p), "singular of %s", p)
+		assert.Equal(t, s, singular(s), "singular of the singular %s", s)
+	}
+}
+
+// TestSingularize provides unit test coverage for Singularize()
+func TestSingularize(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "regular",
+			Template: `{{ singularize "items" }}`,
+			Want:     "item",
+		},
+		{
+			Name:     "irregular, keeping case",
+			Template: `{{ singularize "Children" }}`,
+			Want:     "Child",
+		},
+		{
+			Name:     "phrase",
+			Template: `{{ singularize "open issues" }}`,
+			Want:     "open issue",
+		},
+		{
+			Name:     "already singular",
+			Template: `{{ singularize "address" }}`,
+			Want:     "address",
+		},
+		{
+			Name:     "singular ending in s",
+			Template: `{{ singularize "Gas" }}`,
+			Want:     "Gas",
+		},
+		{
+			Name:     "empty",
+			Template: `{{ singularize "" }}`,
+			Want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestToSentence provides unit test coverage for ToSentence() and ToSentenceWith()
+func TestToSentence(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "three",
+			Template: `{{ toSentence .L }}`,
+			Args:     helper.TestArgs{"L": []string{"a", "b", "c"}},
+			Want:     "a, b and c",
+		},
+		{
+			Name:     "two",
+			Template: `{{ toSentence .L }}`,
+			Args:     helper.TestArgs{"L": []string{"a", "b"}},
+			Want:     "a and b",
+		},
+		{
+			Name:     "one",
+			Template: `{{ toSentence .L }}`,
+			Args:     helper.TestArgs{"L": []int{1}},
+			Want:     "1",
+		},
+		{
+			Name:     "none",
+			Template: `{{ toSentence .L }}`,
+			Args:     helper.TestArgs{"L": []string{}},
+			Want:     "",
+		},
+		{
+			Name:     "oxford comma",
+			Template: `{{ toSentenceWith .O .L }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"oxford": true}, "L": []string{"a", "b", "c"}},
+			Want:     "a, b, and c",
+		},
+		{
+			Name:     "oxford comma with two",
+			Template: `{{ toSentenceWith .O .L }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"oxford": true}, "L": []string{"a", "b"}},
+			Want:     "a and b",
+		},
+		{
+			Name:     "conjunction and separator",
+			Template: `{{ toSentenceWith .O .L }}`,
+			Args: helper.TestArgs{
+				"O": map[string]any{"conjunction": "or", "separator": "; ", "oxford": true},
+				"L": []string{"a", "b", "c"},
+			},
+			Want: "a; b; or c",
+		},
+		{
+			Name:     "unknown option",
+			Template: `{{ toSentenceWith .O .L }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"serial": true}, "L": []string{"a"}},
+			WantErr:  true,
+		},
+		{
+			Name:     "not a list",
+			Template: `{{ toSentence "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestHumanize provides unit test coverage for Humanize()
+func TestHumanize(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "snake case",
+			Template: `{{ humanize "user_id" }}`,
+			Want:     "User id",
+		},
+		{
+			Name:     "camel case",
+			Template: `{{ humanize "createdAt" }}`,
+			Want:     "Created at",
+		},
+		{
+			Name:     "kebab case",
+			Template: `{{ humanize "last-login-time" }}`,
+			Want:     "Last login time",
+		},
+		{
+			Name:     "empty",
+			Template: `{{ humanize "" }}`,
+			Want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestSlugify provides unit test coverage for Slugify()
+func TestSlugify(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "words",
+			Template: `{{ slugify "Hello, World!" }}`,
+			Want:     "hello-world",
+		},
+		{
+			Name:     "accents",
+			Template: `{{ slugify "Crème Brûlée" }}`,
+			Want:     "creme-brulee",
+		},
+		{
+			Name:     "expanded letters",
+			Template: `{{ slugify "Straße Œuvre Æsir" }}`,
+			Want:     "strasse-oeuvre-aesir",
+		},
+		{
+			Name:     "contraction",
+			Template: `{{ slugify "Don't Panic" }}`,
+			Want:     "dont-panic",
+		},
+		{
+			Name:     "leading and trailing punctuation",
+			Template: `{{ slugify "  --Release 1.2.3--  " }}`,
+			Want:     "release-1-2-3",
+		},
+		{
+			Name:     "other scripts",
+			Template: `{{ slugify "Tōkyō 東京" }}`,
+			Want:     "tokyo-東京",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
@@ -0,0 +1,117 @@
+package strings
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// maxCachedRegexes is the most compiled patterns a regexCache holds, so that patterns built from data
+// can't grow it without limit
+const maxCachedRegexes = 128
+
+// regexCache holds the compiled regular expressions, so that patterns used repeatedly (eg within a range)
+// are only compiled once. A new cache is created each time Functions() is called, ie for each template set
+type regexCache struct {
+	mu       sync.Mutex
+	compiled map[string]*regexp.Regexp
+}
+
+// compile returns the compiled pattern, from the cache if it's been seen before.
+// When the cache is full, an arbitrary pattern is dropped to make room
+func (c *regexCache) compile(pattern string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if re, ok := c.compiled[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %s", pattern, strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+	}
+
+	if c.compiled == nil {
+		c.compiled = map[string]*regexp.Regexp{}
+	}
+	if len(c.compiled) >= maxCachedRegexes {
+		for p := range c.compiled {
+			delete(c.compiled, p)
+			break
+		}
+	}
+	c.compiled[pattern] = re
+	return re, nil
+}
+
+// Match returns true if the string contains a match of the pattern
+func (c *regexCache) Match(pattern string, s string) (bool, error) {
+	re, err := c.compile(pattern)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(s), nil
+}
+
+// Find returns the first match of the pattern in the string, or an empty string if there isn't one
+func (c *regexCache) Find(pattern string, s string) (string, error) {
+	re, err := c.compile(pattern)
+	if err != nil {
+		return "", err
+	}
+	return re.FindString(s), nil
+}
+
+// FindAll returns all the (non-overlapping) matches of the pattern in the string
+func (c *regexCache) FindAll(pattern string, s string) ([]string, error) {
+	re, err := c.compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	m := re.FindAllString(s, -1)
+	if m == nil {
+		m = []string{}
+	}
+	return m, nil
+}
+
+// Replace replaces all matches of the pattern in the string with the replacement,
+// in which $1 or ${name} refer to the text matched by the numbered or named groups
+func (c *regexCache) Replace(pattern string, replacement string, s string) (string, error) {
+	re, err := c.compile(pattern)
+	if err != nil {
+		return "", err
+	}
+	return re.ReplaceAllString(s, replacement), nil
+}
+
+// Split splits the string into the parts separated by matches of the pattern
+func (c *regexCache) Split(pattern string, s string) ([]string, error) {
+	re, err := c.compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return re.Split(s, -1), nil
+}
+
+// Groups returns the text matched by each of the named groups in the first match of the pattern,
+// as a dictionary keyed by their names. The dictionary is empty if there's no match
+func (c *regexCache) Groups(pattern string, s string) (map[string]any, error) {
+	re, err := c.compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string]any{}
+	m := re.FindStringSubmatch(s)
+	if m == nil {
+		return groups, nil
+	}
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			groups[name] = m[i]
+		}
+	}
+	return groups, nil
+}
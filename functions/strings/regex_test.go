@@ -0,0 +1,151 @@
+package strings
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegexMatch provides unit test coverage for regexCache.Match() and regexCache.Find()
+func TestRegexMatch(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "matches",
+			Template: `{{ regexMatch "^v[0-9]+$" "v12" }}`,
+			Want:     "true",
+		},
+		{
+			Name:     "doesn't match",
+			Template: `{{ regexMatch "^v[0-9]+$" "version 12" }}`,
+			Want:     "false",
+		},
+		{
+			Name:     "bad pattern",
+			Template: `{{ regexMatch "v(" "v12" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "find",
+			Template: `{{ regexFind "[0-9]+" "abc 123 456" }}`,
+			Want:     "123",
+		},
+		{
+			Name:     "find nothing",
+			Template: `{{ regexFind "[0-9]+" "abc" }}`,
+			Want:     "",
+		},
+		{
+			Name:     "find with bad pattern",
+			Template: `{{ regexFind "[0-9" "abc" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "find all",
+			Template: `{{ range regexFindAll "[0-9]+" "abc 123 456" }}[{{ . }}]{{ end }}`,
+			Want:     "[123][456]",
+		},
+		{
+			Name:     "find all of nothing",
+			Template: `{{ regexFindAll "[0-9]+" "abc" | len }}`,
+			Want:     "0",
+		},
+		{
+			Name:     "find all with bad pattern",
+			Template: `{{ regexFindAll "*" "abc" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestRegexReplace provides unit test coverage for regexCache.Replace() and regexCache.Split()
+func TestRegexReplace(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "numbered groups",
+			Template: `{{ regexReplace "(\\w+)@(\\w+)" "$2 at ${1}_x" "bob@home, sue@work" }}`,
+			Want:     "home at bob_x, work at sue_x",
+		},
+		{
+			Name:     "named groups",
+			Template: `{{ regexReplace "(?P<k>\\w+)=(?P<v>\\w+)" "${v}=${k}" "a=1 b=2" }}`,
+			Want:     "1=a 2=b",
+		},
+		{
+			Name:     "bad pattern",
+			Template: `{{ regexReplace "a)" "" "aa" }}`,
+			WantErr:  true,
+		},
+		{
+			Name:     "split",
+			Template: `{{ range regexSplit "\\s*[,;]\\s*" "a , b;c" }}[{{ . }}]{{ end }}`,
+			Want:     "[a][b][c]",
+		},
+		{
+			Name:     "split with bad pattern",
+			Template: `{{ regexSplit "(?<" "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestRegexGroups provides unit test coverage for regexCache.Groups()
+func TestRegexGroups(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "named groups",
+			Template: `{{ with regexGroups "(?P<major>\\d+)\\.(?P<minor>\\d+)(\\.\\d+)?" "v1.22.3" }}{{ .major }}/{{ .minor }}/{{ len . }}{{ end }}`,
+			Want:     "1/22/2",
+		},
+		{
+			Name:     "unmatched optional group",
+			Template: `{{ with regexGroups "(?P<a>x)|(?P<b>y)" "y" }}[{{ .a }}][{{ .b }}]{{ end }}`,
+			Want:     "[][y]",
+		},
+		{
+			Name:     "no match",
+			Template: `{{ regexGroups "(?P<n>\\d+)" "abc" | len }}`,
+			Want:     "0",
+		},
+		{
+			Name:     "bad pattern",
+			Template: `{{ regexGroups "(?P<n" "abc" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestRegexCache checks that patterns are only compiled once, that errors describe the problem
+// and that the cache is limited in size
+func TestRegexCache(t *testing.T) {
+	c := &regexCache{}
+
+	a, err := c.compile("[a-z]+")
+	require.NoError(t, err)
+	b, err := c.compile("[a-z]+")
+	require.NoError(t, err)
+	assert.Same(t, a, b)
+
+	_, err = c.compile("(abc")
+	assert.EqualError(t, err, "invalid regular expression \"(abc\": missing closing ): `(abc`")
+
+	for i := 0; i < 2*maxCachedRegexes; i++ {
+		_, err = c.compile(fmt.Sprintf("x{%d}", i))
+		require.NoError(t, err)
+	}
+	assert.Len(t, c.compiled, maxCachedRegexes)
+}
@@ -0,0 +1,57 @@
+package strings
+
+import (
+	"strings"
+	"unicode"
+)
+
+// transliterations are the ASCII equivalents of (Latin) accented and other letters
+var transliterations = func() map[rune]string {
+	m := map[rune]string{}
+	for from, to := range map[string]string{
+		"ÀÁÂÃÄÅĀĂĄ": "A", "àáâãäåāăą": "a", "Æ": "AE", "æ": "ae",
+		"ÇĆĈĊČ": "C", "çćĉċč": "c", "ÐĎĐ": "D", "ðďđ": "d",
+		"ÈÉÊËĒĔĖĘĚ": "E", "èéêëēĕėęě": "e", "ĜĞĠĢ": "G", "ĝğġģ": "g",
+		"ĤĦ": "H", "ĥħ": "h", "ÌÍÎÏĨĪĬĮİ": "I", "ìíîïĩīĭįı": "i",
+		"Ĳ": "IJ", "ĳ": "ij", "Ĵ": "J", "ĵ": "j", "Ķ": "K", "ķĸ": "k",
+		"ĹĻĽĿŁ": "L", "ĺļľŀł": "l", "ÑŃŅŇŊ": "N", "ñńņňŉŋ": "n",
+		"ÒÓÔÕÖØŌŎŐ": "O", "òóôõöøōŏő": "o", "Œ": "OE", "œ": "oe",
+		"ŔŖŘ": "R", "ŕŗř": "r", "ŚŜŞŠ": "S", "śŝşšſ": "s", "ß": "ss",
+		"ŢŤŦ": "T", "ţťŧ": "t", "Þ": "TH", "þ": "th",
+		"ÙÚÛÜŨŪŬŮŰŲ": "U", "ùúûüũūŭůűų": "u", "Ŵ": "W", "ŵ": "w",
+		"ÝŸŶ": "Y", "ýÿŷ": "y", "ŹŻŽ": "Z", "źżž": "z",
+	} {
+		for _, r := range from {
+			m[r] = to
+		}
+	}
+	return m
+}()
+
+// Slugify converts text to a form suitable for use in URLs and filenames: lowercase words separated by hyphens,
+// with accented letters replaced by their ASCII equivalents.
+// Letters from other scripts are kept, while punctuation and other characters are removed
+func Slugify(s string) string {
+	var sb strings.Builder
+	gap := false
+	for _, r := range s {
+		var text string
+		if t, ok := transliterations[r]; ok {
+			text = strings.ToLower(t)
+		} else if r == '\'' || r == '’' {
+			continue // keep contractions together, eg "don't" to "dont"
+		} else if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			text = string(unicode.ToLower(r))
+		} else {
+			gap = true
+			continue
+		}
+
+		if gap && sb.Len() > 0 {
+			sb.WriteByte('-')
+		}
+		gap = false
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
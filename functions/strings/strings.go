@@ -17,6 +17,7 @@ import (
 
 // Functions that primarily operate on strings
 func Functions() template.FuncMap {
+	re := &regexCache{}
 	return template.FuncMap{
 		"bracket":            Bracket,
 		"bracketWith":        BracketWith,
@@ -56,6 +57,41 @@ func Functions() template.FuncMap {
 		"hasSuffix":          HasSuffix,
 		"trimPrefix":         TrimPrefix,
 		"trimSuffix":         TrimSuffix,
+		"qEsc":               QuoteSingleEscaped,
+		"qqEsc":              GoString,
+		"qbEsc":              QuoteBackEscaped,
+		"shellQuote":         ShellQuote,
+		"sqlString":          SQLString,
+		"regexQuote":         RegexQuote,
+		"urlQuery":           URLQuery,
+		"urlPath":            URLPath,
+		"xmlEscape":          XMLEscape,
+		"htmlEscape":         HTMLEscape,
+		"jsonString":         JSONString,
+		"goString":           GoString,
+		"regexMatch":         re.Match,
+		"regexFind":          re.Find,
+		"regexFindAll":       re.FindAll,
+		"regexReplace":       re.Replace,
+		"regexSplit":         re.Split,
+		"regexGroups":        re.Groups,
+		"replace":            Replace,
+		"replaceN":           ReplaceN,
+		"substr":             Substr,
+		"truncate":           Truncate,
+		"ellipsisMiddle":     EllipsisMiddle,
+		"count":              Count,
+		"indexOf":            IndexOf,
+		"repeatSep":          RepeatSep,
+		"wrap":               Wrap,
+		"wrapWithIndent":     WrapWithIndent,
+		"center":             Center,
+		"pluralize":          Pluralize,
+		"singularize":        Singularize,
+		"toSentence":         ToSentence,
+		"toSentenceWith":     ToSentenceWith,
+		"humanize":           Humanize,
+		"slugify":            Slugify,
 	}
 }
 
@@ -113,16 +149,18 @@ func Tab(n int) string {
 	return strings.Repeat("\t", n)
 }
 
-// PadRight prints the given string in the given number of columns, right aligned
+// PadRight prints the given string in the given number of columns, left aligned.
+// Columns are counted by display width, so wide characters (eg emoji) and ANSI colour codes are allowed for
 func PadRight(n int, s any) string {
-	f := fmt.Sprintf("%%-%dv", n)
-	return fmt.Sprintf(f, s)
+	str := fmt.Sprint(s)
+	return str + Space(n-helper.DisplayWidth(str))
 }
 
-// PadLeft prints the given string in the given number of columns, left aligned
+// PadLeft prints the given string in the given number of columns, right aligned.
+// Columns are counted by display width, so wide characters (eg emoji) and ANSI colour codes are allowed for
 func PadLeft(n int, s any) string {
-	f := fmt.Sprintf("%%%dv", n)
-	return fmt.Sprintf(f, s)
+	str := fmt.Sprint(s)
+	return Space(n-helper.DisplayWidth(str)) + str
 }
 
 // Bracket adds brackets around the given string
@@ -240,72 +278,15 @@ func Suffix(suffix string, count int, content string) string {
 	return strings.Join(newParts, "\n")
 }
 
-// ToColumn formats the given text to not take more than 'w' characters per line,
+// ToColumn formats the given text to not take more than the given width per line,
 // splitting on the space before the word that would take the line over.
-// If no space can be found, the line isn't split (ie words bigger than the line size are printed un-split)
+// Words longer than the width are left intact, and each line of the text is wrapped separately
 func ToColumn(width int, content string) string {
-	var b strings.Builder
-	tail := ""
-
-	parts := strings.Split(content, "\n")
-	for _, p := range parts {
-		str := tail + p
-		tail = ""
-
-		lines := columnify(width, str)
-		if len(lines) > 1 {
-			tail = lines[len(lines)-1]
-		}
-
-		numLines := len(lines)
-		for i, l := range lines {
-			if i > 0 && i == numLines-1 {
-				tail = l
-				break
-			}
-
-			b.WriteString(l)
-			b.WriteByte('\n')
-		}
-	}
-
-	if len(tail) > 0 {
-		b.WriteString(tail)
-		b.WriteByte('\n')
-	}
-
-	return b.String()
-}
-
-// columnify is a helper method for ToColumn to split lines on spaces
-func columnify(w int, s string) []string {
-	var lines []string
-
-	var at, i int
-	for at < len(s) {
-		i = at + w
-		if i >= len(s) {
-			lines = append(lines, s[at:])
-			break
-		}
-
-		//revive:disable-next-line:empty-block
-		for ; i > at && s[i] != ' '; i-- {
-			// looking backwards for a space
-		}
-
-		if i == at { // didn't find one
-			//revive:disable-next-line:empty-block
-			for i = at + w; i < len(s) && s[i] != ' '; i++ {
-				// looking forwards for a space
-			}
-		}
-
-		lines = append(lines, s[at:i])
-		at = i + 1
+	if content == "" {
+		return ""
 	}
-
-	return lines
+	lines := wrapLines(width, width, strings.TrimSuffix(content, "\n"), false)
+	return strings.Join(lines, "\n") + "\n"
 }
 
 // SplitOn creates an array from the given string by separating it by the glue string
@@ -12,7 +12,7 @@ import (
 // TestFunctions provides unit test coverage for StringFunctions
 func TestFunctions(t *testing.T) {
 	fn := Functions()
-	assert.Len(t, fn, 38, "weakly ensuring functions haven't been added/removed without updating tests")
+	assert.Len(t, fn, 73, "weakly ensuring functions haven't been added/removed without updating tests")
 }
 
 func TestUppercaseFirst(t *testing.T) {
@@ -480,6 +480,33 @@ func TestPadRight(t *testing.T) {
 			},
 			Want: "basic     ",
 		},
+		{
+			Name:     "wide characters",
+			Template: `{{ padRight .N .S }}|`,
+			Args: helper.TestArgs{
+				"N": 6,
+				"S": "日本",
+			},
+			Want: "日本  |",
+		},
+		{
+			Name:     "colour codes",
+			Template: `{{ padRight .N .S }}|`,
+			Args: helper.TestArgs{
+				"N": 4,
+				"S": "\x1b[31mok\x1b[0m",
+			},
+			Want: "\x1b[31mok\x1b[0m  |",
+		},
+		{
+			Name:     "too long",
+			Template: `{{ padRight .N .S }}`,
+			Args: helper.TestArgs{
+				"N": 2,
+				"S": "basic",
+			},
+			Want: "basic",
+		},
 	}
 
 	for _, tt := range tests {
@@ -498,6 +525,24 @@ func TestPadLeft(t *testing.T) {
 			},
 			Want: "     basic",
 		},
+		{
+			Name:     "emoji",
+			Template: `|{{ padLeft .N .S }}`,
+			Args: helper.TestArgs{
+				"N": 5,
+				"S": "🚀 x",
+			},
+			Want: "| 🚀 x",
+		},
+		{
+			Name:     "number",
+			Template: `{{ padLeft .N .S }}`,
+			Args: helper.TestArgs{
+				"N": 4,
+				"S": 12,
+			},
+			Want: "  12",
+		},
 	}
 
 	for _, tt := range tests {
@@ -804,7 +849,25 @@ func TestToColumn(t *testing.T) {
 				"W": 3,
 				"S": "foo\n\n\n\nbar\n\n\n\n\n",
 			},
-			Want: "foo\nbar\n",
+			Want: "foo\n\n\n\nbar\n\n\n\n\n",
+		},
+		{
+			Name:     "wrapped line before a newline",
+			Template: "{{ toColumns .W .S }}",
+			Args: helper.TestArgs{
+				"W": 7,
+				"S": "foo bar baz\nsnk",
+			},
+			Want: "foo bar\nbaz\nsnk\n",
+		},
+		{
+			Name:     "display width",
+			Template: "{{ toColumns .W .S }}",
+			Args: helper.TestArgs{
+				"W": 5,
+				"S": "日本 語の テキ スト",
+			},
+			Want: "日本\n語の\nテキ\nスト\n",
 		},
 	}
 
@@ -0,0 +1,132 @@
+package strings
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// Wrap formats the given text to not take more than the given width per line, breaking lines between words.
+// Widths are measured as displayed in a terminal, so wide characters (eg emoji) and ANSI colour codes are allowed for.
+// Words longer than the width are broken wherever required (without hyphens), and existing newlines are kept
+func Wrap(width int, content string) (string, error) {
+	if width < 1 {
+		return "", errors.New("cannot wrap to a width of less than 1")
+	}
+	return strings.Join(wrapLines(width, width, content, true), "\n"), nil
+}
+
+// WrapWithIndent wraps the given text like Wrap, but indents the lines after the first line
+// of each paragraph with the given indent, ie a hanging indent. The width includes the indent
+func WrapWithIndent(width int, indent string, content string) (string, error) {
+	cont := width - helper.DisplayWidth(indent)
+	if width < 1 || cont < 1 {
+		return "", fmt.Errorf("cannot wrap to a width of %d with an indent of %q", width, indent)
+	}
+
+	var res []string
+	for _, p := range strings.Split(content, "\n") {
+		lines := wrapLines(width, cont, p, true)
+		for i := 1; i < len(lines); i++ {
+			lines[i] = indent + lines[i]
+		}
+		res = append(res, lines...)
+	}
+	return strings.Join(res, "\n"), nil
+}
+
+// Center prints the given string in the middle of the given number of columns,
+// with any odd space on the right. Columns are counted by display width
+func Center(n int, s any) string {
+	str := fmt.Sprint(s)
+	pad := n - helper.DisplayWidth(str)
+	if pad <= 0 {
+		return str
+	}
+	return Space(pad/2) + str + Space(pad-pad/2)
+}
+
+// wrapLines wraps each line of the content separately, to width for the first line of each
+// and cont for the remainder. Over-long words are broken if hard is set
+func wrapLines(width, cont int, content string, hard bool) []string {
+	var res []string
+	for _, l := range strings.Split(content, "\n") {
+		res = append(res, wrapLine(width, cont, l, hard)...)
+	}
+	return res
+}
+
+// wrapLine wraps a single line. Spaces within the line are kept, apart from those where it's broken
+func wrapLine(width, cont int, line string, hard bool) []string {
+	var lines []string
+	limit := func() int {
+		if len(lines) == 0 {
+			return width
+		}
+		return cont
+	}
+
+	var cur strings.Builder
+	curW := 0
+	broken := false // a line has just been broken, so spaces are skipped until the next word
+	for i, w := range strings.Split(line, " ") {
+		ww := helper.DisplayWidth(w)
+		switch {
+		case broken && w == "":
+			continue
+		case broken:
+			broken = false
+		case i > 0 && curW+1+ww <= limit():
+			cur.WriteByte(' ')
+			cur.WriteString(w)
+			curW += 1 + ww
+			continue
+		case i > 0:
+			lines = append(lines, strings.TrimRight(cur.String(), " "))
+			cur.Reset()
+			if w == "" {
+				broken = true
+				continue
+			}
+		}
+
+		for hard && ww > limit() {
+			head, rest := breakWord(w, limit())
+			if rest == "" { // a single character wider than the line
+				break
+			}
+			lines = append(lines, head)
+			w, ww = rest, helper.DisplayWidth(rest)
+		}
+		cur.WriteString(w)
+		curW = ww
+	}
+
+	if broken {
+		return lines
+	}
+	return append(lines, cur.String())
+}
+
+// breakWord splits a word into the part that fits within the width, and the remainder.
+// At least one character is always taken, so that progress is made for widths narrower than a character
+func breakWord(w string, width int) (string, string) {
+	used := 0
+	for i := 0; i < len(w); {
+		if n := helper.EscapeLength(w[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(w[i:])
+		rw := helper.RuneWidth(r)
+		if used > 0 && used+rw > width {
+			return w[:i], w[i:]
+		}
+		used += rw
+		i += size
+	}
+	return w, ""
+}
@@ -0,0 +1,142 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWrap provides unit test coverage for Wrap()
+func TestWrap(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "words",
+			Template: `{{ wrap 10 "the quick brown fox jumps over" }}`,
+			Want:     "the quick\nbrown fox\njumps over",
+		},
+		{
+			Name:     "long word",
+			Template: `{{ wrap 4 "a abcdefghij b" }}`,
+			Want:     "a\nabcd\nefgh\nij b",
+		},
+		{
+			Name:     "newlines kept",
+			Template: `{{ wrap 10 .S }}`,
+			Args:     helper.TestArgs{"S": "foo bar baz\n\n  indented text\n"},
+			Want:     "foo bar\nbaz\n\n  indented\ntext\n",
+		},
+		{
+			Name:     "wide characters",
+			Template: `{{ wrap 5 "日本語のテキスト" }}`,
+			Want:     "日本\n語の\nテキ\nスト",
+		},
+		{
+			Name:     "wider than the width",
+			Template: `{{ wrap 1 "日本" }}`,
+			Want:     "日\n本",
+		},
+		{
+			Name:     "colour codes",
+			Template: `{{ wrap 5 .S }}`,
+			Args:     helper.TestArgs{"S": "\x1b[1mbold\x1b[0m and \x1b[32mgreen\x1b[0m"},
+			Want:     "\x1b[1mbold\x1b[0m\nand\n\x1b[32mgreen\x1b[0m",
+		},
+		{
+			Name:     "spacing kept",
+			Template: `{{ wrap 8 "a  b   c    dd" }}`,
+			Want:     "a  b   c\ndd",
+		},
+		{
+			Name:     "bad width",
+			Template: `{{ wrap 0 "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestWrapWithIndent provides unit test coverage for WrapWithIndent()
+func TestWrapWithIndent(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "hanging indent",
+			Template: `{{ wrapWithIndent 16 "    " "-f  overwrite the output file if it exists" }}`,
+			Want:     "-f  overwrite\n    the output\n    file if it\n    exists",
+		},
+		{
+			Name:     "spaces at the break",
+			Template: `{{ wrapWithIndent 8 "  " "-f  overwrite" }}`,
+			Want:     "-f\n  overwr\n  ite",
+		},
+		{
+			Name:     "each paragraph",
+			Template: `{{ wrapWithIndent 6 "  " .S }}`,
+			Args:     helper.TestArgs{"S": "aa bb cc\ndd ee ff"},
+			Want:     "aa bb\n  cc\ndd ee\n  ff",
+		},
+		{
+			Name:     "indent too wide",
+			Template: `{{ wrapWithIndent 4 "    " "a" }}`,
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestCenter provides unit test coverage for Center()
+func TestCenter(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "even",
+			Template: `|{{ center 7 "abc" }}|`,
+			Want:     "|  abc  |",
+		},
+		{
+			Name:     "odd space",
+			Template: `|{{ center 6 "abc" }}|`,
+			Want:     "| abc  |",
+		},
+		{
+			Name:     "wide characters",
+			Template: `|{{ center 8 "日本" }}|`,
+			Want:     "|  日本  |",
+		},
+		{
+			Name:     "too long",
+			Template: `|{{ center 2 "abc" }}|`,
+			Want:     "|abc|",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestDisplayWidth checks the width calculations used by the wrapping and padding functions
+func TestDisplayWidth(t *testing.T) {
+	tests := map[string]int{
+		"":                                   0,
+		"abc":                                3,
+		"résumé":                             6,
+		"é":                                 1, // combining accent
+		"日本語":                                6,
+		"ｆｕｌｌ":                               8,
+		"🚀":                                  2,
+		"👩‍💻":                                2, // joined emoji
+		"\x1b[1;31mred\x1b[0m":               3,
+		"\x1b]8;;http://x\x07go\x1b]8;;\x07": 2, // hyperlink
+		"tab\there":                          7,
+	}
+
+	for s, want := range tests {
+		assert.Equal(t, want, helper.DisplayWidth(s), "%q", s)
+	}
+}
@@ -0,0 +1,293 @@
+package table
+
+import (
+	"encoding/csv"
+	"strings"
+
+	"github.com/mantidtech/tplr/functions/helper"
+	tstrings "github.com/mantidtech/tplr/functions/strings"
+)
+
+// border holds the characters used to draw a table
+type border struct {
+	horizontal, vertical               string
+	topLeft, topMiddle, topRight       string
+	midLeft, midMiddle, midRight       string
+	bottomLeft, bottomMid, bottomRight string
+	outline                            bool // whether the table has borders, or just aligned columns
+}
+
+var borders = map[string]border{
+	"plain": {vertical: " "},
+	"ascii": {
+		horizontal: "-", vertical: "|",
+		topLeft: "+", topMiddle: "+", topRight: "+",
+		midLeft: "+", midMiddle: "+", midRight: "+",
+		bottomLeft: "+", bottomMid: "+", bottomRight: "+",
+		outline: true,
+	},
+	"box": {
+		horizontal: "─", vertical: "│",
+		topLeft: "┌", topMiddle: "┬", topRight: "┐",
+		midLeft: "├", midMiddle: "┼", midRight: "┤",
+		bottomLeft: "└", bottomMid: "┴", bottomRight: "┘",
+		outline: true,
+	},
+}
+
+// overhead returns the width taken by the borders and padding of a table with n columns
+func (b border) overhead(n int) int {
+	if b.outline {
+		return 3*n + 1
+	}
+	return 2 * (n - 1)
+}
+
+// renderText draws the table with aligned columns
+func renderText(b border, cols []column, cells [][]string, header bool, width int) string {
+	if len(cols) == 0 {
+		return ""
+	}
+
+	rows := cells
+	if header {
+		h := make([]string, len(cols))
+		for i, c := range cols {
+			h[i] = c.header
+		}
+		rows = append([][]string{h}, cells...)
+	}
+
+	widths := columnWidths(b, cols, rows, width)
+	lines := make([][][]string, len(rows)) // the wrapped lines of each cell
+	for r, row := range rows {
+		lines[r] = make([][]string, len(cols))
+		for c, v := range row {
+			lines[r][c] = wrapCell(v, widths[c], width > 0)
+		}
+	}
+	for c := range widths { // wrapping can leave a column narrower, or long words wider (with no width limit), than requested
+		widths[c] = 0
+		for r := range lines {
+			for _, l := range lines[r][c] {
+				widths[c] = max(widths[c], helper.DisplayWidth(l))
+			}
+		}
+	}
+
+	var sb strings.Builder
+	rule := func(left, middle, right string) {
+		if !b.outline {
+			return
+		}
+		sb.WriteString(left)
+		for c, w := range widths {
+			if c > 0 {
+				sb.WriteString(middle)
+			}
+			sb.WriteString(strings.Repeat(b.horizontal, w+2))
+		}
+		sb.WriteString(right + "\n")
+	}
+
+	rule(b.topLeft, b.topMiddle, b.topRight)
+	for r := range lines {
+		writeRow(&sb, b, cols, widths, lines[r])
+		if r == 0 && header {
+			rule(b.midLeft, b.midMiddle, b.midRight)
+		}
+	}
+	rule(b.bottomLeft, b.bottomMid, b.bottomRight)
+	return sb.String()
+}
+
+// writeRow writes each of the lines of a row of (wrapped) cells
+func writeRow(sb *strings.Builder, b border, cols []column, widths []int, cells [][]string) {
+	height := 1
+	for _, c := range cells {
+		height = max(height, len(c))
+	}
+
+	for i := 0; i < height; i++ {
+		var line strings.Builder
+		if b.outline {
+			line.WriteString(b.vertical + " ")
+		}
+		for c, col := range cols {
+			if c > 0 {
+				if b.outline {
+					line.WriteString(" " + b.vertical + " ")
+				} else {
+					line.WriteString("  ")
+				}
+			}
+			v := ""
+			if i < len(cells[c]) {
+				v = cells[c][i]
+			}
+			line.WriteString(align(col.align, widths[c], v))
+		}
+		if b.outline {
+			line.WriteString(" " + b.vertical)
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+}
+
+func align(how string, width int, v string) string {
+	switch how {
+	case "right":
+		return tstrings.PadLeft(width, v)
+	case "center":
+		return tstrings.Center(width, v)
+	}
+	return tstrings.PadRight(width, v)
+}
+
+// columnWidths returns the width of each column, limited by the maximum width of the column, and of the table
+func columnWidths(b border, cols []column, rows [][]string, width int) []int {
+	widths := make([]int, len(cols))
+	for c, col := range cols {
+		for _, row := range rows {
+			for _, l := range strings.Split(row[c], "\n") {
+				widths[c] = max(widths[c], helper.DisplayWidth(l))
+			}
+		}
+		if col.maxWidth > 0 && widths[c] > col.maxWidth {
+			widths[c] = col.maxWidth
+		}
+	}
+
+	if width <= 0 {
+		return widths
+	}
+	// narrow the widest column until the table fits, or none can be narrowed any further
+	const minWidth = 3
+	for total := b.overhead(len(cols)) + sum(widths); total > width; total-- {
+		widest := 0
+		for c := range widths {
+			if widths[c] > widths[widest] {
+				widest = c
+			}
+		}
+		if widths[widest] <= minWidth {
+			break
+		}
+		widths[widest]--
+	}
+	return widths
+}
+
+func sum(n []int) int {
+	s := 0
+	for _, i := range n {
+		s += i
+	}
+	return s
+}
+
+// wrapCell splits the value into lines no wider than the width. Words that are too long are
+// broken if hard is set, or otherwise left on lines of their own
+func wrapCell(v string, width int, hard bool) []string {
+	if hard && width > 0 {
+		w, _ := tstrings.Wrap(width, strings.TrimSuffix(v, "\n"))
+		return strings.Split(w, "\n")
+	}
+	return strings.Split(strings.TrimSuffix(tstrings.ToColumn(width, v), "\n"), "\n")
+}
+
+// renderMarkdown writes the table as a (github flavoured) markdown table
+func renderMarkdown(cols []column, cells [][]string) string {
+	if len(cols) == 0 {
+		return ""
+	}
+	escape := strings.NewReplacer("|", `\|`, "\n", "<br>")
+
+	rows := make([][]string, len(cells)+1)
+	rows[0] = make([]string, len(cols))
+	widths := make([]int, len(cols))
+	for c, col := range cols {
+		rows[0][c] = escape.Replace(col.header)
+		widths[c] = max(3, helper.DisplayWidth(rows[0][c]))
+	}
+	for r, row := range cells {
+		rows[r+1] = make([]string, len(cols))
+		for c, v := range row {
+			rows[r+1][c] = escape.Replace(v)
+			widths[c] = max(widths[c], helper.DisplayWidth(rows[r+1][c]))
+		}
+	}
+
+	var sb strings.Builder
+	write := func(row []string) {
+		sb.WriteString("|")
+		for c, v := range row {
+			sb.WriteString(" " + align(cols[c].align, widths[c], v) + " |")
+		}
+		sb.WriteString("\n")
+	}
+
+	write(rows[0])
+	sb.WriteString("|")
+	for c, col := range cols {
+		rule := strings.Repeat("-", widths[c])
+		switch col.align {
+		case "right":
+			rule = rule[1:] + ":"
+		case "center":
+			rule = ":" + rule[2:] + ":"
+		}
+		sb.WriteString(" " + rule + " |")
+	}
+	sb.WriteString("\n")
+	for _, r := range rows[1:] {
+		write(r)
+	}
+	return sb.String()
+}
+
+// renderCSV writes the table as csv
+func renderCSV(cols []column, cells [][]string, header bool) (string, error) {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	if header {
+		if err := w.Write(headers(cols)); err != nil {
+			return "", err
+		}
+	}
+	if err := w.WriteAll(cells); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// renderTSV writes the table as tab separated values, with any tabs or newlines in the values replaced by spaces
+func renderTSV(cols []column, cells [][]string, header bool) string {
+	clean := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ")
+	var sb strings.Builder
+	write := func(row []string) {
+		for c, v := range row {
+			if c > 0 {
+				sb.WriteString("\t")
+			}
+			sb.WriteString(clean.Replace(v))
+		}
+		sb.WriteString("\n")
+	}
+
+	if header {
+		write(headers(cols))
+	}
+	for _, r := range cells {
+		write(r)
+	}
+	return sb.String()
+}
+
+func headers(cols []column) []string {
+	h := make([]string, len(cols))
+	for i, c := range cols {
+		h[i] = c.header
+	}
+	return h
+}
@@ -0,0 +1,263 @@
+// Package table provides template functions for rendering lists as text tables
+package table
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/mantidtech/tplr/functions/console"
+	"github.com/mantidtech/tplr/functions/helper"
+)
+
+// Functions for rendering tables
+func Functions() template.FuncMap {
+	return template.FuncMap{
+		"table":     Table,
+		"tableWith": TableWith,
+	}
+}
+
+// column describes how to display one of the columns of a table
+type column struct {
+	key      string // the key of the value in map rows, or its (0 based) index in list rows
+	header   string
+	align    string
+	maxWidth int
+}
+
+// Table returns the rows (a list of dictionaries or a list of lists) as an aligned text table,
+// using the default options of TableWith
+func Table(rows any) (string, error) {
+	return TableWith(nil, rows)
+}
+
+// TableWith returns the rows (a list of dictionaries or a list of lists) as a text table.
+// The options (a dictionary) are:
+//
+//	style   - one of "plain" (aligned columns), "ascii", "box" (box drawing characters), "markdown", "csv" or "tsv"
+//	          (default "plain")
+//	columns - a list of the columns to show, each either the key (or header, for list rows) or a dictionary of:
+//	          key (the dictionary key or list index of the value), header, align ("left", "right" or "center")
+//	          and maxWidth (wrapping longer values). By default, all the keys of dictionary rows are shown in sorted order
+//	header  - whether to show the column headers (default true). Markdown tables always have a header row
+//	width   - the maximum width of the table, with the widest columns wrapped (and long words broken) to fit
+//	          (default no limit)
+//	fit     - fit the table to the width of the terminal, if there is one (default false)
+func TableWith(opts any, rows any) (string, error) {
+	o, err := helper.NewOptions(opts, "style", "columns", "header", "width", "fit")
+	if err != nil {
+		return "", err
+	}
+	style, err := o.String("style", "plain")
+	if err != nil {
+		return "", err
+	}
+	header, err := o.Bool("header", true)
+	if err != nil {
+		return "", err
+	}
+	if style == "markdown" && !header {
+		return "", errors.New("markdown tables always have a header row")
+	}
+	width, err := o.Int("width", 0)
+	if err != nil {
+		return "", err
+	}
+	fit, err := o.Bool("fit", false)
+	if err != nil {
+		return "", err
+	}
+	if tw := console.TerminalWidth(); fit && tw > 0 && (width == 0 || tw < width) {
+		width = tw
+	}
+
+	list, l, err := helper.ListInfo(rows)
+	if err != nil {
+		return "", fmt.Errorf("table rows should be a list: %w", err)
+	}
+	records := make([]any, l)
+	for i := range records {
+		records[i] = list.Index(i).Interface()
+	}
+
+	cols, err := columns(o["columns"], records)
+	if err != nil {
+		return "", err
+	}
+	if header {
+		header = hasHeaders(cols)
+	}
+
+	cells, err := values(cols, records)
+	if err != nil {
+		return "", err
+	}
+
+	switch style {
+	case "csv":
+		return renderCSV(cols, cells, header)
+	case "tsv":
+		return renderTSV(cols, cells, header), nil
+	case "markdown":
+		return renderMarkdown(cols, cells), nil
+	}
+
+	b, ok := borders[style]
+	if !ok {
+		return "", fmt.Errorf("unknown table style %q, expected one of ascii, box, csv, markdown, plain or tsv", style)
+	}
+	return renderText(b, cols, cells, header, width), nil
+}
+
+// columns returns the columns from the specs, or if there are none, determined from the rows
+func columns(specs any, rows []any) ([]column, error) {
+	if specs == nil {
+		return defaultColumns(rows), nil
+	}
+
+	list, l, err := helper.ListInfo(specs)
+	if err != nil {
+		return nil, fmt.Errorf("table columns should be a list: %w", err)
+	}
+	listRows := len(rows) > 0 && !isMap(rows[0])
+
+	cols := make([]column, l)
+	for i := range cols {
+		c := &cols[i]
+		c.align = "left"
+		switch s := list.Index(i).Interface().(type) {
+		case string:
+			c.key, c.header = s, s
+			if listRows {
+				c.key = strconv.Itoa(i)
+			}
+		default:
+			spec, errS := helper.NewOptions(s, "key", "header", "align", "maxWidth")
+			if errS != nil {
+				return nil, fmt.Errorf("table column %d: %w", i+1, errS)
+			}
+			if err = c.configure(i, spec, listRows); err != nil {
+				return nil, fmt.Errorf("table column %d: %w", i+1, err)
+			}
+		}
+	}
+	return cols, nil
+}
+
+// configure sets up a column from a dictionary spec
+func (c *column) configure(i int, spec helper.Options, listRows bool) error {
+	if k, ok := spec["key"]; ok {
+		c.key = fmt.Sprint(k)
+	} else if listRows {
+		c.key = strconv.Itoa(i)
+	}
+
+	var err error
+	if c.header, err = spec.String("header", c.key); err != nil {
+		return err
+	}
+	if !listRows && c.key == "" {
+		c.key = c.header
+	}
+	if c.align, err = spec.String("align", "left"); err != nil {
+		return err
+	}
+	if c.align != "left" && c.align != "right" && c.align != "center" {
+		return fmt.Errorf("align should be one of left, right or center, not %q", c.align)
+	}
+	if c.maxWidth, err = spec.Int("maxWidth", 0); err != nil {
+		return err
+	}
+	if c.maxWidth < 0 {
+		return fmt.Errorf("maxWidth should not be negative, got %d", c.maxWidth)
+	}
+	return nil
+}
+
+// defaultColumns returns all the keys of dictionary rows, or the (header-less) positions of list rows
+func defaultColumns(rows []any) []column {
+	keys := map[string]bool{}
+	n := 0
+	for _, r := range rows {
+		if m, err := helper.AsMap(r); err == nil {
+			for k := range m {
+				keys[k] = true
+			}
+		} else if _, l, errL := helper.ListInfo(r); errL == nil && l > n {
+			n = l
+		}
+	}
+
+	var cols []column
+	if len(keys) > 0 {
+		names := make([]string, 0, len(keys))
+		for k := range keys {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, k := range names {
+			cols = append(cols, column{key: k, header: k, align: "left"})
+		}
+		return cols
+	}
+
+	for i := 0; i < n; i++ {
+		cols = append(cols, column{key: strconv.Itoa(i), align: "left"})
+	}
+	return cols
+}
+
+func isMap(v any) bool {
+	_, err := helper.AsMap(v)
+	return err == nil
+}
+
+func hasHeaders(cols []column) bool {
+	for _, c := range cols {
+		if c.header != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// values returns the text of each cell of the table
+func values(cols []column, rows []any) ([][]string, error) {
+	cells := make([][]string, len(rows))
+	for r, row := range rows {
+		cells[r] = make([]string, len(cols))
+
+		if m, err := helper.AsMap(row); err == nil {
+			for c, col := range cols {
+				cells[r][c] = cellText(m[col.key])
+			}
+			continue
+		}
+
+		list, l, err := helper.ListInfo(row)
+		if err != nil {
+			return nil, fmt.Errorf("table row %d should be a dictionary or list: %w", r+1, err)
+		}
+		for c, col := range cols {
+			i, err := strconv.Atoi(col.key)
+			if err != nil {
+				return nil, fmt.Errorf("table row %d is a list, so column %q needs a numeric key", r+1, col.key)
+			}
+			if i >= 0 && i < l {
+				cells[r][c] = cellText(list.Index(i).Interface())
+			}
+		}
+	}
+	return cells, nil
+}
+
+func cellText(v any) string {
+	if v == nil {
+		return ""
+	}
+	return strings.TrimSuffix(fmt.Sprint(v), "\n")
+}
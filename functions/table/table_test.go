@@ -0,0 +1,267 @@
+package table
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mantidtech/tplr/functions/console"
+	"github.com/mantidtech/tplr/functions/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// TestFunctions provides unit test coverage for Functions
+func TestFunctions(t *testing.T) {
+	fn := Functions()
+	assert.Len(t, fn, 2, "weakly ensuring functions haven't been added/removed without updating tests")
+}
+
+var people = []any{
+	map[string]any{"name": "Bob", "age": 42, "note": "a long note that wraps"},
+	map[string]any{"name": "Alice", "age": 7},
+}
+
+var peopleColumns = []any{
+	"name",
+	map[string]any{"key": "age", "align": "right"},
+	map[string]any{"key": "note", "header": "Note", "maxWidth": 10},
+}
+
+// TestTable provides unit test coverage for Table()
+func TestTable(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "list of maps",
+			Template: `{{ table .R }}`,
+			Args:     helper.TestArgs{"R": people},
+			Want: "" +
+				"age  name   note\n" +
+				"42   Bob    a long note that wraps\n" +
+				"7    Alice\n",
+		},
+		{
+			Name:     "list of lists",
+			Template: `{{ table .R }}`,
+			Args:     helper.TestArgs{"R": [][]any{{1, 2}, {"x", "yy", "zzz"}}},
+			Want: "" +
+				"1  2\n" +
+				"x  yy  zzz\n",
+		},
+		{
+			Name:     "wide and coloured characters",
+			Template: `{{ table .R }}`,
+			Args: helper.TestArgs{"R": []any{
+				map[string]any{"a": "日本", "b": 1},
+				map[string]any{"a": "\x1b[31mred\x1b[0m", "b": 2},
+			}},
+			Want: "" +
+				"a     b\n" +
+				"日本  1\n" +
+				"\x1b[31mred\x1b[0m   2\n",
+		},
+		{
+			Name:     "empty",
+			Template: `{{ table .R }}`,
+			Args:     helper.TestArgs{"R": []any{}},
+			Want:     "",
+		},
+		{
+			Name:     "not a list",
+			Template: `{{ table .R }}`,
+			Args:     helper.TestArgs{"R": "rows"},
+			WantErr:  true,
+		},
+		{
+			Name:     "row not a map or list",
+			Template: `{{ table .R }}`,
+			Args:     helper.TestArgs{"R": []any{[]any{1}, 2}},
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestTableWith provides unit test coverage for TableWith()
+func TestTableWith(t *testing.T) {
+	tests := []helper.TestSet{
+		{
+			Name:     "plain with columns",
+			Template: `{{ tableWith .O .R }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"columns": peopleColumns}, "R": people},
+			Want: "" +
+				"name   age  Note\n" +
+				"Bob     42  a long\n" +
+				"            note that\n" +
+				"            wraps\n" +
+				"Alice    7\n",
+		},
+		{
+			Name:     "ascii",
+			Template: `{{ tableWith .O .R }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"style": "ascii", "columns": peopleColumns}, "R": people},
+			Want: "" +
+				"+-------+-----+-----------+\n" +
+				"| name  | age | Note      |\n" +
+				"+-------+-----+-----------+\n" +
+				"| Bob   |  42 | a long    |\n" +
+				"|       |     | note that |\n" +
+				"|       |     | wraps     |\n" +
+				"| Alice |   7 |           |\n" +
+				"+-------+-----+-----------+\n",
+		},
+		{
+			Name:     "box without header",
+			Template: `{{ tableWith .O .R }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"style": "box", "header": false, "columns": []any{"name", "age"}}, "R": people},
+			Want: "" +
+				"┌───────┬────┐\n" +
+				"│ Bob   │ 42 │\n" +
+				"│ Alice │ 7  │\n" +
+				"└───────┴────┘\n",
+		},
+		{
+			Name:     "markdown",
+			Template: `{{ tableWith .O .R }}`,
+			Args: helper.TestArgs{
+				"O": map[string]any{"style": "markdown", "columns": []any{
+					map[string]any{"key": "name", "align": "center"},
+					map[string]any{"key": "age", "align": "right"},
+					"note",
+				}},
+				"R": append([]any{map[string]any{"name": "Carol", "note": "a|b\nc"}}, people...),
+			},
+			Want: "" +
+				"| name  | age | note                   |\n" +
+				"| :---: | --: | ---------------------- |\n" +
+				"| Carol |     | a\\|b<br>c              |\n" +
+				"|  Bob  |  42 | a long note that wraps |\n" +
+				"| Alice |   7 |                        |\n",
+		},
+		{
+			Name:     "markdown without header",
+			Template: `{{ tableWith .O .R }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"style": "markdown", "header": false}, "R": people},
+			WantErr:  true,
+		},
+		{
+			Name:     "csv",
+			Template: `{{ tableWith .O .R }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"style": "csv"}, "R": people},
+			Want: "" +
+				"age,name,note\n" +
+				"42,Bob,a long note that wraps\n" +
+				"7,Alice,\n",
+		},
+		{
+			Name:     "tsv",
+			Template: `{{ tableWith .O .R }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"style": "tsv", "columns": []any{"name", "note"}}, "R": people},
+			Want: "" +
+				"name\tnote\n" +
+				"Bob\ta long note that wraps\n" +
+				"Alice\t\n",
+		},
+		{
+			Name:     "list rows with headers",
+			Template: `{{ tableWith .O .R }}`,
+			Args: helper.TestArgs{
+				"O": map[string]any{"columns": []any{"first", map[string]any{"key": 2, "header": "third"}}},
+				"R": [][]any{{1, 2, 3}, {4, 5}},
+			},
+			Want: "" +
+				"first  third\n" +
+				"1      3\n" +
+				"4\n",
+		},
+		{
+			Name:     "width",
+			Template: `{{ tableWith .O .R }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"style": "ascii", "width": 24}, "R": people},
+			Want: "" +
+				"+-----+-------+--------+\n" +
+				"| age | name  | note   |\n" +
+				"+-----+-------+--------+\n" +
+				"| 42  | Bob   | a long |\n" +
+				"|     |       | note   |\n" +
+				"|     |       | that   |\n" +
+				"|     |       | wraps  |\n" +
+				"| 7   | Alice |        |\n" +
+				"+-----+-------+--------+\n",
+		},
+		{
+			Name:     "width with long words",
+			Template: `{{ tableWith .O .R }}`,
+			Args: helper.TestArgs{
+				"O": map[string]any{"style": "box", "width": 20},
+				"R": []any{map[string]any{"word": "abcdefghijklmnopqrst"}},
+			},
+			Want: "" +
+				"┌──────────────────┐\n" +
+				"│ word             │\n" +
+				"├──────────────────┤\n" +
+				"│ abcdefghijklmnop │\n" +
+				"│ qrst             │\n" +
+				"└──────────────────┘\n",
+		},
+		{
+			Name:     "unknown style",
+			Template: `{{ tableWith .O .R }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"style": "fancy"}, "R": people},
+			WantErr:  true,
+		},
+		{
+			Name:     "unknown option",
+			Template: `{{ tableWith .O .R }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"colour": true}, "R": people},
+			WantErr:  true,
+		},
+		{
+			Name:     "bad alignment",
+			Template: `{{ tableWith .O .R }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"columns": []any{map[string]any{"key": "age", "align": "middle"}}}, "R": people},
+			WantErr:  true,
+		},
+		{
+			Name:     "non-numeric key for list rows",
+			Template: `{{ tableWith .O .R }}`,
+			Args:     helper.TestArgs{"O": map[string]any{"columns": []any{map[string]any{"key": "a"}}}, "R": [][]any{{1}}},
+			WantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, helper.TemplateTest(tt, Functions()))
+	}
+}
+
+// TestTableWithFit provides unit test coverage for TableWith() fitting the table to the terminal
+func TestTableWithFit(t *testing.T) {
+	orig := console.GetWinsize
+	t.Cleanup(func() { console.GetWinsize = orig })
+	console.GetWinsize = func() (*unix.Winsize, error) {
+		return nil, errors.New("not a terminal")
+	}
+
+	opts := map[string]any{"fit": true, "columns": []any{"name", "note"}}
+
+	t.Setenv("COLUMNS", "")
+	got, err := TableWith(opts, people)
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"name   note\n"+
+		"Bob    a long note that wraps\n"+
+		"Alice\n", got, "without a terminal the width shouldn't be limited")
+
+	t.Setenv("COLUMNS", "20")
+	got, err = TableWith(opts, people)
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"name   note\n"+
+		"Bob    a long note\n"+
+		"       that wraps\n"+
+		"Alice\n", got)
+}
@@ -4,22 +4,62 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math/rand"
+	"sync"
 	"text/template"
+	"time"
 
 	"github.com/mantidtech/tplr/functions"
+	"github.com/mantidtech/tplr/functions/console"
+	"github.com/mantidtech/tplr/functions/helper"
 )
 
 // Tplr manages loading and rendering templates
 type Tplr struct {
 	name     string
+	seed     *int64
+	now      *time.Time
+	color    *console.ColorMode
 	Template *template.Template
 }
 
+// Option configures a tplr instance
+type Option func(t *Tplr)
+
+// WithSeed seeds the random functions before each render so that the output is reproducible
+func WithSeed(seed int64) Option {
+	return func(t *Tplr) {
+		t.seed = &seed
+	}
+}
+
+// WithNow fixes the current time used by the time functions so that the output is reproducible
+func WithNow(now time.Time) Option {
+	return func(t *Tplr) {
+		t.now = &now
+	}
+}
+
+// WithColor sets when the colour and style functions add ANSI escape codes to their output
+func WithColor(mode console.ColorMode) Option {
+	return func(t *Tplr) {
+		t.color = &mode
+	}
+}
+
+// renderLock makes renders that override the shared random source, clock or colour mode run on their own,
+// so that the overrides only apply to their own output.  Other renders can run concurrently
+var renderLock sync.RWMutex
+
 // New creates a new tplr instance
-func New(name string) *Tplr {
-	return &Tplr{
+func New(name string, opts ...Option) *Tplr {
+	t := &Tplr{
 		name: name,
 	}
+	for _, o := range opts {
+		o(t)
+	}
+	return t
 }
 
 // Load a template from the supplied Reader and create a new Template object
@@ -43,6 +83,30 @@ func (t *Tplr) Load(r io.Reader) error {
 
 // Generate text from the template and data supplied and writes it to the given Writer
 func (t *Tplr) Generate(w io.Writer, vars map[string]any) error {
+	if t.seed == nil && t.now == nil && t.color == nil {
+		renderLock.RLock()
+		defer renderLock.RUnlock()
+	} else {
+		renderLock.Lock()
+		defer renderLock.Unlock()
+	}
+
+	if t.seed != nil {
+		defer func(r *rand.Rand) { helper.Random = r }(helper.Random)
+		helper.Random = helper.NewRandom(*t.seed)
+	}
+	if t.now != nil {
+		defer func(fn func() time.Time) { helper.Now = fn }(helper.Now)
+		now := *t.now
+		helper.Now = func() time.Time {
+			return now
+		}
+	}
+	if t.color != nil {
+		defer func(m console.ColorMode) { console.ColorOutput = m }(console.ColorOutput)
+		console.ColorOutput = *t.color
+	}
+
 	var err error
 	var f bytes.Buffer
 	err = t.Template.ExecuteTemplate(&f, t.name, vars)
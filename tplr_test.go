@@ -2,8 +2,12 @@ package tplr
 
 import (
 	"bytes"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/mantidtech/tplr/functions/console"
+	"github.com/mantidtech/tplr/functions/helper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -67,3 +71,78 @@ func TestLoadAndGenerateFromTemplate(t *testing.T) {
 		})
 	}
 }
+
+// TestWithSeed ensures that seeded instances render the random functions reproducibly
+func TestWithSeed(t *testing.T) {
+	render := func(opts ...Option) string {
+		tp := New("TestWithSeed", opts...)
+		require.NoError(t, tp.Load(bytes.NewBufferString(`{{ randAlnum 32 }}`)))
+
+		var got bytes.Buffer
+		require.NoError(t, tp.Generate(&got, nil))
+		return got.String()
+	}
+
+	a := render(WithSeed(7))
+	assert.Len(t, a, 32)
+	assert.Equal(t, a, render(WithSeed(7)))
+	assert.NotEqual(t, a, render(WithSeed(8)))
+
+	prev := helper.Random
+	render(WithSeed(7))
+	assert.Same(t, prev, helper.Random, "the shared source is restored after a seeded render")
+	assert.NotEqual(t, a, render(), "unseeded renders don't continue the seeded sequence")
+
+	var wg sync.WaitGroup
+	got := make([]string, 8)
+	for i := range got {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			got[i] = render(WithSeed(int64(7 + i%2)))
+		}(i)
+	}
+	wg.Wait()
+	for i, g := range got {
+		assert.Equal(t, render(WithSeed(int64(7+i%2))), g, "concurrent seeded renders are reproducible")
+	}
+}
+
+// TestWithNow ensures that the current time can be fixed
+func TestWithNow(t *testing.T) {
+	tp := New("TestWithNow", WithNow(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	require.NoError(t, tp.Load(bytes.NewBufferString(`{{ now }} {{ timeAdd "1d" | timeFormat "2006-01-02" }}`)))
+
+	var got bytes.Buffer
+	require.NoError(t, tp.Generate(&got, nil))
+	assert.Equal(t, "2024-01-01T00:00:00Z 2024-01-02", got.String())
+
+	tp = New("TestWithoutNow")
+	require.NoError(t, tp.Load(bytes.NewBufferString(`{{ now "2006" }}`)))
+	got.Reset()
+	require.NoError(t, tp.Generate(&got, nil))
+	assert.NotEqual(t, "2024", got.String(), "the current time is restored after a render with a fixed time")
+}
+
+// TestWithColor ensures that colour can be turned on or off
+func TestWithColor(t *testing.T) {
+	defer func(m console.ColorMode) { console.ColorOutput = m }(console.ColorOutput)
+
+	render := func(opts ...Option) string {
+		tp := New("TestWithColor", opts...)
+		require.NoError(t, tp.Load(bytes.NewBufferString(`{{ bold "text" }}`)))
+
+		var got bytes.Buffer
+		require.NoError(t, tp.Generate(&got, nil))
+		return got.String()
+	}
+
+	console.ColorOutput = console.ColorNever
+	assert.Equal(t, "\x1b[1mtext\x1b[22m", render(WithColor(console.ColorAlways)))
+	assert.Equal(t, console.ColorNever, console.ColorOutput, "the previous mode should be restored after rendering")
+	assert.Equal(t, "text", render(), "a later render without WithColor should use the previous mode")
+
+	console.ColorOutput = console.ColorAlways
+	assert.Equal(t, "text", render(WithColor(console.ColorNever)))
+	assert.Equal(t, "\x1b[1mtext\x1b[22m", render())
+}